@@ -0,0 +1,65 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// ErrNoActionStarted is returned by StartAction when the response holds no action
+var ErrNoActionStarted = errors.New("no action started")
+
+// StartAction requests an action and returns the ID and status of the action created,
+// to be polled with FetchActions. Unsuccessful responses are returned as errors. The
+// API does not support the Prefer: respond-async header: heavy work is run
+// asynchronously by requesting an action instead.
+func (c *CompaniesAPIClient) StartAction(ctx context.Context, body RequestActionJSONRequestBody, reqEditors ...RequestEditorFn) (actionId float32, status string, err error) {
+	response, err := c.RequestAction(ctx, body, reqEditors...)
+	if err != nil {
+		return 0, "", err
+	}
+	if response.StatusCode() != http.StatusOK {
+		return 0, "", c.baseClient.responseError(response.HTTPResponse, response.Body)
+	}
+	if response.JSON200 == nil || len(response.JSON200.Actions) == 0 {
+		return 0, "", ErrNoActionStarted
+	}
+	action := response.JSON200.Actions[0]
+	return action.Id, string(action.Status), nil
+}
+
+// FetchActionsByStatus fetches the actions with the given status (Pending, Active,
+// Completed or Failed). The other filters of params, which may be nil, are kept.
+func (c *CompaniesAPIClient) FetchActionsByStatus(ctx context.Context, status FetchActionsParamsStatus, params *FetchActionsParams, reqEditors ...RequestEditorFn) (*FetchActionsResponse, error) {
+	filtered := copyFetchActionsParams(params)
+	filtered.Status = &status
+	return c.FetchActions(ctx, filtered, reqEditors...)
+}
+
+// FetchActionsByType fetches the actions of the given type (e.g. FetchActionsParamsTypeJobsRequest).
+// The other filters of params, which may be nil, are kept.
+func (c *CompaniesAPIClient) FetchActionsByType(ctx context.Context, actionType FetchActionsParamsType, params *FetchActionsParams, reqEditors ...RequestEditorFn) (*FetchActionsResponse, error) {
+	filtered := copyFetchActionsParams(params)
+	filtered.Type = &actionType
+	return c.FetchActions(ctx, filtered, reqEditors...)
+}
+
+// FetchPendingActions fetches the actions waiting to be processed
+func (c *CompaniesAPIClient) FetchPendingActions(ctx context.Context, reqEditors ...RequestEditorFn) (*FetchActionsResponse, error) {
+	return c.FetchActionsByStatus(ctx, Pending, nil, reqEditors...)
+}
+
+// FetchActiveActions fetches the actions currently being processed
+func (c *CompaniesAPIClient) FetchActiveActions(ctx context.Context, reqEditors ...RequestEditorFn) (*FetchActionsResponse, error) {
+	return c.FetchActionsByStatus(ctx, Active, nil, reqEditors...)
+}
+
+// copyFetchActionsParams returns a shallow copy of params so that filters can be set
+// without mutating the caller's struct
+func copyFetchActionsParams(params *FetchActionsParams) *FetchActionsParams {
+	if params == nil {
+		return &FetchActionsParams{}
+	}
+	copied := *params
+	return &copied
+}
@@ -0,0 +1,80 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestFetchActionsFilters(t *testing.T) {
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"actions":[{"id":1,"status":"pending"}],"meta":{"total":1}}`))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	ctx := context.Background()
+	response, err := client.FetchPendingActions(ctx)
+	if err != nil {
+		t.Fatalf("FetchPendingActions failed: %v", err)
+	}
+	if response.JSON200 == nil || len(response.JSON200.Actions) != 1 {
+		t.Fatalf("Expected one action, got %s", response.Body)
+	}
+
+	page := float32(2)
+	params := &FetchActionsParams{Page: &page}
+	if _, err := client.FetchActionsByStatus(ctx, Completed, params); err != nil {
+		t.Fatalf("FetchActionsByStatus failed: %v", err)
+	}
+	if params.Status != nil {
+		t.Error("Expected the caller params to be left untouched")
+	}
+	if _, err := client.FetchActionsByType(ctx, FetchActionsParamsTypeJobsRequest, nil); err != nil {
+		t.Fatalf("FetchActionsByType failed: %v", err)
+	}
+
+	if queries[0].Get("status") != "pending" {
+		t.Errorf("Expected status=pending, got %q", queries[0].Get("status"))
+	}
+	if queries[1].Get("status") != "completed" || queries[1].Get("page") != "2" {
+		t.Errorf("Expected status=completed&page=2, got %q", queries[1].Encode())
+	}
+	if queries[2].Get("type") != "jobs:request" {
+		t.Errorf("Expected type=jobs:request, got %q", queries[2].Get("type"))
+	}
+}
+
+func TestStartAction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected a POST request, got %s", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"actions":[{"id":42,"status":"pending","type":"jobs:request"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	ctx := context.Background()
+	actionId, status, err := client.StartAction(ctx, RequestActionJSONRequestBody{Type: RequestActionJSONBodyTypeJobsRequest})
+	if err != nil {
+		t.Fatalf("StartAction failed: %v", err)
+	}
+	if actionId != 42 || status != "pending" {
+		t.Errorf("Expected action 42 pending, got %v %q", actionId, status)
+	}
+}
@@ -0,0 +1,176 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// AnalyticsBucket is a value of an analytics breakdown with the number of companies
+// having it
+type AnalyticsBucket struct {
+	Name  string
+	Count int64
+	// PercentageOfAll is the share of all the companies of the query having the value
+	PercentageOfAll float64
+	// PercentageOfTotal is the share of the values of the breakdown
+	PercentageOfTotal float64
+}
+
+// AnalyticsBreakdown is the distribution of the companies of a query over the values
+// of an attribute
+type AnalyticsBreakdown struct {
+	Attribute       FetchCompaniesAnalyticsParamsAttribute
+	Buckets         []AnalyticsBucket
+	TotalDocuments  int64
+	TotalValues     int64
+	TotalDatapoints int64
+}
+
+// Each calls fn for every bucket in the order returned by the API until fn returns
+// false. A nil breakdown, such as the one of an attribute that was not requested, has
+// no buckets.
+func (b *AnalyticsBreakdown) Each(fn func(bucket AnalyticsBucket) bool) {
+	if b == nil {
+		return
+	}
+	for _, bucket := range b.Buckets {
+		if !fn(bucket) {
+			return
+		}
+	}
+}
+
+// Bucket returns the bucket of a value, reporting false when there is none
+func (b *AnalyticsBreakdown) Bucket(name string) (AnalyticsBucket, bool) {
+	if b == nil {
+		return AnalyticsBucket{}, false
+	}
+	for _, bucket := range b.Buckets {
+		if bucket.Name == name {
+			return bucket, true
+		}
+	}
+	return AnalyticsBucket{}, false
+}
+
+// Series returns the bucket names and counts as parallel slices, ready to be charted,
+// nil for a nil breakdown
+func (b *AnalyticsBreakdown) Series() (names []string, counts []int64) {
+	if b == nil {
+		return nil, nil
+	}
+	names = make([]string, len(b.Buckets))
+	counts = make([]int64, len(b.Buckets))
+	for i, bucket := range b.Buckets {
+		names[i], counts[i] = bucket.Name, bucket.Count
+	}
+	return names, counts
+}
+
+// AnalyticsResult gathers the breakdowns of a query over several attributes
+type AnalyticsResult struct {
+	Breakdowns map[FetchCompaniesAnalyticsParamsAttribute]*AnalyticsBreakdown
+}
+
+// Breakdown returns the breakdown of an attribute, nil when it was not requested
+func (r *AnalyticsResult) Breakdown(attribute FetchCompaniesAnalyticsParamsAttribute) *AnalyticsBreakdown {
+	return r.Breakdowns[attribute]
+}
+
+// ByIndustry returns the breakdown by main industry
+func (r *AnalyticsResult) ByIndustry() *AnalyticsBreakdown {
+	return r.Breakdown(FetchCompaniesAnalyticsParamsAttributeAboutIndustry)
+}
+
+// ByCountry returns the breakdown by headquarters country code
+func (r *AnalyticsResult) ByCountry() *AnalyticsBreakdown {
+	return r.Breakdown(FetchCompaniesAnalyticsParamsAttributeLocationsHeadquartersCountryCode)
+}
+
+// BySize returns the breakdown by employee count range
+func (r *AnalyticsResult) BySize() *AnalyticsBreakdown {
+	return r.Breakdown(FetchCompaniesAnalyticsParamsAttributeAboutTotalEmployees)
+}
+
+// DefaultAnalyticsAttributes are the attributes fetched by FetchCompaniesAnalyticsResult
+// when none is given: industry, country and size
+var DefaultAnalyticsAttributes = []FetchCompaniesAnalyticsParamsAttribute{
+	FetchCompaniesAnalyticsParamsAttributeAboutIndustry,
+	FetchCompaniesAnalyticsParamsAttributeLocationsHeadquartersCountryCode,
+	FetchCompaniesAnalyticsParamsAttributeAboutTotalEmployees,
+}
+
+// FetchCompaniesAnalyticsResult fetches the analytics of the query of params (which may
+// be nil) for each attribute, DefaultAnalyticsAttributes when none is given, and
+// returns them as a typed AnalyticsResult. The Attribute of params is ignored.
+//
+// The query conditions are sent JSON encoded like MakeRequestWithQuery does, which
+// the generated FetchCompaniesAnalytics cannot serialize.
+func (c *CompaniesAPIClient) FetchCompaniesAnalyticsResult(ctx context.Context, params *FetchCompaniesAnalyticsParams, attributes ...FetchCompaniesAnalyticsParamsAttribute) (*AnalyticsResult, error) {
+	if len(attributes) == 0 {
+		attributes = DefaultAnalyticsAttributes
+	}
+	if params == nil {
+		params = &FetchCompaniesAnalyticsParams{}
+	}
+
+	result := &AnalyticsResult{Breakdowns: make(map[FetchCompaniesAnalyticsParamsAttribute]*AnalyticsBreakdown, len(attributes))}
+	for _, attribute := range attributes {
+		path := "/v2/companies/analytics?" + c.baseClient.BuildQueryString(map[string]interface{}{
+			"attribute": string(attribute),
+			"actionId":  params.ActionId,
+			"listId":    params.ListId,
+			"query":     params.Query,
+			"size":      params.Size,
+			"sort":      params.Sort,
+		})
+		body, err := c.makeRequest(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var response analyticsResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal analytics response: %w", err)
+		}
+		result.Breakdowns[attribute] = newAnalyticsBreakdown(attribute, &response)
+	}
+	return result, nil
+}
+
+// analyticsResponse is the body of a successful FetchCompaniesAnalytics response
+type analyticsResponse struct {
+	Data []struct {
+		Count             float64 `json:"count"`
+		Name              string  `json:"name"`
+		PercentageOfAll   float64 `json:"percentageOfAll"`
+		PercentageOfTotal float64 `json:"percentageOfTotal"`
+	} `json:"data"`
+	Meta struct {
+		TotalDatapoints float64 `json:"totalDatapoints"`
+		TotalDocuments  float64 `json:"totalDocuments"`
+		TotalValues     float64 `json:"totalValues"`
+	} `json:"meta"`
+}
+
+// newAnalyticsBreakdown converts a successful analytics response
+func newAnalyticsBreakdown(attribute FetchCompaniesAnalyticsParamsAttribute, response *analyticsResponse) *AnalyticsBreakdown {
+	breakdown := &AnalyticsBreakdown{
+		Attribute:       attribute,
+		Buckets:         make([]AnalyticsBucket, len(response.Data)),
+		TotalDocuments:  int64(response.Meta.TotalDocuments),
+		TotalValues:     int64(response.Meta.TotalValues),
+		TotalDatapoints: int64(response.Meta.TotalDatapoints),
+	}
+	for i, data := range response.Data {
+		breakdown.Buckets[i] = AnalyticsBucket{
+			Name:              data.Name,
+			Count:             int64(data.Count),
+			PercentageOfAll:   data.PercentageOfAll,
+			PercentageOfTotal: data.PercentageOfTotal,
+		}
+	}
+	return breakdown
+}
@@ -0,0 +1,87 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestFetchCompaniesAnalyticsResult(t *testing.T) {
+	responses := map[string]string{
+		"about.industry": `{"data":[
+			{"name":"software","count":1200,"percentageOfAll":12,"percentageOfTotal":60},
+			{"name":"banking","count":800,"percentageOfAll":8,"percentageOfTotal":40}
+		],"meta":{"query":[],"totalDatapoints":2,"totalDocuments":10000,"totalValues":2000}}`,
+		"locations.headquarters.country.code": `{"data":[
+			{"name":"us","count":700,"percentageOfAll":7,"percentageOfTotal":70},
+			{"name":"fr","count":300,"percentageOfAll":3,"percentageOfTotal":30}
+		],"meta":{"query":[],"totalDatapoints":2,"totalDocuments":10000,"totalValues":1000}}`,
+		"about.totalEmployees": `{"data":[
+			{"name":"1-10","count":5000,"percentageOfAll":50,"percentageOfTotal":100}
+		],"meta":{"query":[],"totalDatapoints":1,"totalDocuments":10000,"totalValues":5000}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Query().Get("attribute")]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	result, err := client.FetchCompaniesAnalyticsResult(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("FetchCompaniesAnalyticsResult returned error: %v", err)
+	}
+
+	industries := result.ByIndustry()
+	if industries == nil || industries.TotalDocuments != 10000 || len(industries.Buckets) != 2 {
+		t.Fatalf("Unexpected industry breakdown: %+v", industries)
+	}
+	if bucket, ok := industries.Bucket("banking"); !ok || bucket.Count != 800 || bucket.PercentageOfTotal != 40 {
+		t.Errorf("Unexpected banking bucket: %+v", bucket)
+	}
+	if _, ok := industries.Bucket("retail"); ok {
+		t.Error("Expected no retail bucket")
+	}
+
+	names, counts := result.ByCountry().Series()
+	if !reflect.DeepEqual(names, []string{"us", "fr"}) || !reflect.DeepEqual(counts, []int64{700, 300}) {
+		t.Errorf("Unexpected country series: %v %v", names, counts)
+	}
+
+	var visited []string
+	result.BySize().Each(func(bucket AnalyticsBucket) bool {
+		visited = append(visited, bucket.Name)
+		return true
+	})
+	if !reflect.DeepEqual(visited, []string{"1-10"}) {
+		t.Errorf("Unexpected size buckets: %v", visited)
+	}
+
+	// The breakdowns of attributes that were not requested are nil and empty
+	missing := result.Breakdown(FetchCompaniesAnalyticsParamsAttributeApps)
+	if _, ok := missing.Bucket("software"); ok {
+		t.Error("Expected no bucket in a missing breakdown")
+	}
+	if names, counts := missing.Series(); names != nil || counts != nil {
+		t.Errorf("Expected no series for a missing breakdown, got %v %v", names, counts)
+	}
+	missing.Each(func(bucket AnalyticsBucket) bool {
+		t.Errorf("Unexpected bucket in a missing breakdown: %+v", bucket)
+		return true
+	})
+
+	if _, err := client.FetchCompaniesAnalyticsResult(context.Background(), nil, FetchCompaniesAnalyticsParamsAttributeApps); err == nil {
+		t.Error("Expected an error for an unsuccessful response")
+	}
+}
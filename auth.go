@@ -0,0 +1,59 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TokenProvider returns the API token used to authenticate a request
+type TokenProvider func(ctx context.Context) (string, error)
+
+// WithTokenProvider obtains the API token from provider instead of the static API key,
+// for environments relying on short-lived credentials. Tokens are cached for ttl and
+// refreshed once expired, a zero ttl invoking the provider before every request. A
+// cached token is discarded as soon as the API answers 401 Unauthorized.
+func WithTokenProvider(provider TokenProvider, ttl time.Duration) BaseClientOption {
+	return func(c *BaseClient) {
+		c.tokens = &tokenCache{provider: provider, ttl: ttl}
+	}
+}
+
+// tokenCache caches the tokens returned by a TokenProvider until they expire
+type tokenCache struct {
+	provider TokenProvider
+	ttl      time.Duration
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// get returns the cached token or obtains a fresh one from the provider
+func (t *tokenCache) get(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" && time.Now().Before(t.expiresAt) {
+		return t.token, nil
+	}
+
+	token, err := t.provider(ctx)
+	if err != nil {
+		return "", err
+	}
+	if t.ttl > 0 {
+		t.token, t.expiresAt = token, time.Now().Add(t.ttl)
+	}
+	return token, nil
+}
+
+// invalidate discards the cached token when it was rejected by the API
+func (t *tokenCache) invalidate(token string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token == token {
+		t.token, t.expiresAt = "", time.Time{}
+	}
+}
@@ -0,0 +1,74 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithTokenProvider(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get("Authorization"))
+		if r.Header.Get("Authorization") == "Basic token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"code":"unauthorized","message":"expired token"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	t.Run("rotating tokens", func(t *testing.T) {
+		received = nil
+		calls := 0
+		client := NewBaseClient("static-key", WithCustomBaseURL(server.URL), WithTokenProvider(func(ctx context.Context) (string, error) {
+			calls++
+			return fmt.Sprintf("token-%d", calls), nil
+		}, 0))
+
+		client.MakeRequest(ctx, "GET", "/", nil)
+		client.MakeRequest(ctx, "GET", "/", nil)
+		client.MakeRequest(ctx, "GET", "/", nil)
+
+		expected := []string{"Basic token-1", "Basic token-2", "Basic token-3"}
+		if fmt.Sprint(received) != fmt.Sprint(expected) {
+			t.Errorf("Expected %v, got %v", expected, received)
+		}
+	})
+
+	t.Run("cached until rejected", func(t *testing.T) {
+		received = nil
+		calls := 1
+		client := NewBaseClient("static-key", WithCustomBaseURL(server.URL), WithTokenProvider(func(ctx context.Context) (string, error) {
+			calls++
+			return fmt.Sprintf("token-%d", calls), nil
+		}, time.Hour))
+
+		client.MakeRequest(ctx, "GET", "/", nil)
+		client.MakeRequest(ctx, "GET", "/", nil)
+		client.MakeRequest(ctx, "GET", "/", nil)
+
+		expected := []string{"Basic token-2", "Basic token-3", "Basic token-3"}
+		if fmt.Sprint(received) != fmt.Sprint(expected) {
+			t.Errorf("Expected %v, got %v", expected, received)
+		}
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		providerErr := errors.New("vault unavailable")
+		client := NewBaseClient("static-key", WithCustomBaseURL(server.URL), WithTokenProvider(func(ctx context.Context) (string, error) {
+			return "", providerErr
+		}, time.Hour))
+
+		if _, err := client.MakeRequest(ctx, "GET", "/", nil); !errors.Is(err, providerErr) {
+			t.Errorf("Expected the provider error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,180 @@
+package thecompaniesapi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// ErrRequestBodyTooLarge is returned when a request body exceeds the limit set with
+// WithRequestBodyLimit
+var ErrRequestBodyTooLarge = errors.New("request body too large")
+
+// WithStreamingRequestBody makes MakeRequest encode JSON bodies on the fly while they
+// are sent, instead of marshaling them into memory first. The elements of a body that
+// is a slice or an array are encoded one at a time into a buffer of streamChunkSize
+// bytes, so that the encoding of a large list is never held in memory as a whole.
+// Other bodies are encoded at once by encoding/json and sent in chunks. Streamed
+// bodies are sent with chunked transfer encoding and re-encoded when a request is
+// retried.
+func WithStreamingRequestBody() BaseClientOption {
+	return func(c *BaseClient) {
+		c.streamRequestBody = true
+	}
+}
+
+// WithRequestBodyLimit rejects MakeRequest bodies whose JSON encoding exceeds limit
+// bytes with ErrRequestBodyTooLarge. Streamed bodies are aborted once the limit is
+// reached.
+func WithRequestBodyLimit(limit int64) BaseClientOption {
+	return func(c *BaseClient) {
+		c.requestBodyLimit = limit
+	}
+}
+
+// requestBody encodes the JSON body of a MakeRequest call. getBody is set for
+// streamed bodies, which cannot be replayed by the HTTP client on its own.
+func (c *BaseClient) requestBody(body any) (reader io.Reader, getBody func() (io.ReadCloser, error), err error) {
+	if body == nil {
+		return nil, nil, nil
+	}
+
+	if c.streamRequestBody {
+		getBody = func() (io.ReadCloser, error) {
+			return c.streamJSON(body), nil
+		}
+		reader, _ = getBody()
+		return reader, getBody, nil
+	}
+
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+	if c.requestBodyLimit > 0 && int64(len(jsonBody)) > c.requestBodyLimit {
+		return nil, nil, fmt.Errorf("%w: %d bytes exceed the limit of %d", ErrRequestBodyTooLarge, len(jsonBody), c.requestBodyLimit)
+	}
+	return bytes.NewBuffer(jsonBody), nil, nil
+}
+
+// streamChunkSize is the size of the writes of streamed request bodies
+const streamChunkSize = 32 * 1024
+
+// streamJSON encodes body into a pipe as it is read. The encoding goroutine ends when
+// the body is fully read or when the reader is closed.
+func (c *BaseClient) streamJSON(body any) io.ReadCloser {
+	reader, writer := io.Pipe()
+	go func() {
+		var w io.Writer = writer
+		if c.requestBodyLimit > 0 {
+			w = &limitedWriter{w: writer, remaining: c.requestBodyLimit}
+		}
+		buffered := bufio.NewWriterSize(w, streamChunkSize)
+		err := encodeJSONStream(buffered, body)
+		if err == nil {
+			err = buffered.WriteByte('\n')
+		}
+		if err == nil {
+			err = buffered.Flush()
+		}
+		if errors.Is(err, ErrRequestBodyTooLarge) {
+			writer.CloseWithError(fmt.Errorf("%w: the encoded body exceeds the limit of %d bytes", ErrRequestBodyTooLarge, c.requestBodyLimit))
+			return
+		}
+		if err != nil {
+			writer.CloseWithError(fmt.Errorf("failed to marshal request body: %w", err))
+			return
+		}
+		writer.Close()
+	}()
+	return reader
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// encodeJSONStream writes the JSON encoding of body to w as json.Marshal would. The
+// elements of a top-level slice or array are encoded one at a time with json.Marshal;
+// any other value is left to json.Marshal at once, like []byte and the types with
+// their own MarshalJSON or MarshalText method.
+func encodeJSONStream(w *bufio.Writer, body any) error {
+	v := reflect.ValueOf(body)
+	for v.Kind() == reflect.Pointer && !v.IsNil() && !marshalsItself(v.Type()) {
+		v = v.Elem()
+	}
+	switch {
+	case v.Kind() != reflect.Slice && v.Kind() != reflect.Array,
+		v.Kind() == reflect.Slice && (v.IsNil() || v.Type().Elem().Kind() == reflect.Uint8),
+		marshalsItself(v.Type()):
+		return writeMarshaled(w, body)
+	}
+
+	if err := w.WriteByte('['); err != nil {
+		return err
+	}
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			if err := w.WriteByte(','); err != nil {
+				return err
+			}
+		}
+		element := v.Index(i)
+		value := element.Interface()
+		// json.Marshal calls the methods declared on the pointer of addressable elements
+		if element.CanAddr() && marshalsItself(reflect.PointerTo(element.Type())) {
+			value = element.Addr().Interface()
+		}
+		if err := writeMarshaled(w, value); err != nil {
+			return err
+		}
+	}
+	return w.WriteByte(']')
+}
+
+// marshalsItself reports whether a type or its pointer has its own JSON encoding
+func marshalsItself(t reflect.Type) bool {
+	for _, candidate := range []reflect.Type{t, reflect.PointerTo(t)} {
+		if candidate.Implements(jsonMarshalerType) || candidate.Implements(textMarshalerType) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeMarshaled writes the json.Marshal encoding of a value
+func writeMarshaled(w *bufio.Writer, value any) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(encoded)
+	return err
+}
+
+// limitedWriter fails with ErrRequestBodyTooLarge once more than remaining bytes are written
+type limitedWriter struct {
+	w         io.Writer
+	remaining int64
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) > l.remaining {
+		return 0, ErrRequestBodyTooLarge
+	}
+	l.remaining -= int64(len(p))
+	return l.w.Write(p)
+}
+
+// closeRequestBody releases a request body that will not be sent
+func closeRequestBody(body io.Reader) {
+	if closer, ok := body.(io.Closer); ok {
+		closer.Close()
+	}
+}
@@ -0,0 +1,190 @@
+package thecompaniesapi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+// largeBody builds a list of domains of about 8MB
+func largeBody() []string {
+	domains := make([]string, 400000)
+	for i := range domains {
+		domains[i] = fmt.Sprintf("company-%07d.com", i)
+	}
+	return domains
+}
+
+// testID is an array type encoded as text, like uuid.UUID
+type testID [4]byte
+
+func (id testID) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%x", id[:])), nil
+}
+
+// testPointerMarshaler has a MarshalJSON method on its pointer only
+type testPointerMarshaler struct{ Value int }
+
+func (m *testPointerMarshaler) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"value %d"`, m.Value)), nil
+}
+
+// recordingWriter records the size of the largest write it receives
+type recordingWriter struct {
+	total, largest int
+}
+
+func (r *recordingWriter) Write(p []byte) (int, error) {
+	r.total += len(p)
+	r.largest = max(r.largest, len(p))
+	return len(p), nil
+}
+
+func TestWithStreamingRequestBody(t *testing.T) {
+	var received [sha256.Size]byte
+	var contentLength int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hash := sha256.New()
+		io.Copy(hash, r.Body)
+		copy(received[:], hash.Sum(nil))
+		contentLength = r.ContentLength
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	body := largeBody()
+	encoded, _ := json.Marshal(body)
+	expected := sha256.Sum256(append(encoded, '\n'))
+
+	client := NewBaseClient("test-api-key", WithCustomBaseURL(server.URL), WithStreamingRequestBody())
+	if _, err := client.MakeRequest(context.Background(), "POST", "/v2/companies/list", body); err != nil {
+		t.Fatalf("MakeRequest returned error: %v", err)
+	}
+	if received != expected {
+		t.Error("Expected the streamed body to match its JSON encoding")
+	}
+	if contentLength != -1 {
+		t.Errorf("Expected a chunked body, got a content length of %d", contentLength)
+	}
+
+	// The encoding is written in chunks rather than built in memory as a whole
+	var recorder recordingWriter
+	buffered := bufio.NewWriterSize(&recorder, streamChunkSize)
+	if err := encodeJSONStream(buffered, body); err != nil {
+		t.Fatalf("encodeJSONStream returned error: %v", err)
+	}
+	buffered.Flush()
+	if recorder.total != len(encoded) || recorder.largest > streamChunkSize {
+		t.Errorf("Expected %d bytes written by chunks of at most %d bytes, got %d bytes and a %d bytes write",
+			len(encoded), streamChunkSize, recorder.total, recorder.largest)
+	}
+}
+
+func TestEncodeJSONStream(t *testing.T) {
+	var value SegmentationCondition_Values_Item
+	value.FromSegmentationConditionValues0("software <&>")
+	query := []SegmentationCondition{testCondition(SegmentationConditionAttributeAboutIndustries, And)}
+	query[0].Values = []SegmentationCondition_Values_Item{value}
+	page := float32(2)
+	ids := []testID{{1, 2, 3, 4}, {5, 6, 7, 8}}
+
+	for _, body := range []any{
+		FetchCompaniesInListPostJSONRequestBody{Query: &query, Page: &page},
+		SearchCompaniesPostJSONRequestBody{},
+		map[string]any{"b": []any{1, "two", nil, map[string]bool{"c": true}}, "a": []byte("raw")},
+		query,
+		&query,
+		ids,
+		&ids,
+		testID{9, 10, 11, 12},
+		[]netip.Addr{netip.MustParseAddr("192.0.2.1"), netip.MustParseAddr("2001:db8::1")},
+		[]testPointerMarshaler{{Value: 1}, {Value: 2}},
+		[2]testPointerMarshaler{{Value: 1}, {Value: 2}},
+		[]byte("raw"),
+		[3]byte{1, 2, 3},
+		[]string(nil),
+		[]any{},
+		struct {
+			Name    string   `json:"name"`
+			Skipped string   `json:"-"`
+			Empty   []string `json:"empty,omitempty"`
+			Nil     []string `json:"nil"`
+			hidden  int
+			Default int
+		}{Name: "acme", Skipped: "x", hidden: 1},
+	} {
+		expected, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("json.Marshal returned error: %v", err)
+		}
+		var encoded bytes.Buffer
+		buffered := bufio.NewWriter(&encoded)
+		if err := encodeJSONStream(buffered, body); err != nil {
+			t.Fatalf("encodeJSONStream returned error: %v", err)
+		}
+		buffered.Flush()
+		if encoded.String() != string(expected) {
+			t.Errorf("Expected %s, got %s", expected, encoded.String())
+		}
+	}
+}
+
+func TestStreamingRequestBodyRetry(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewBaseClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithStreamingRequestBody(),
+		WithRetry(2),
+		WithBackoffStrategy(ConstantBackoff{}),
+	)
+	if _, err := client.MakeRequest(context.Background(), "POST", "/v2/companies", map[string]string{"search": "saas"}); err != nil {
+		t.Fatalf("MakeRequest returned error: %v", err)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] || bodies[1] != "{\"search\":\"saas\"}\n" {
+		t.Errorf("Expected the streamed body to be replayed, got %q", bodies)
+	}
+}
+
+func TestWithRequestBodyLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	body := map[string]string{"search": "a search longer than the limit"}
+	for _, streaming := range []bool{false, true} {
+		options := []BaseClientOption{WithCustomBaseURL(server.URL), WithRequestBodyLimit(16)}
+		if streaming {
+			options = append(options, WithStreamingRequestBody())
+		}
+		client := NewBaseClient("test-api-key", options...)
+
+		if _, err := client.MakeRequest(context.Background(), "POST", "/v2/companies", body); !errors.Is(err, ErrRequestBodyTooLarge) {
+			t.Errorf("Expected ErrRequestBodyTooLarge (streaming %v), got %v", streaming, err)
+		}
+		if _, err := client.MakeRequest(context.Background(), "POST", "/v2/companies", map[string]int{"a": 1}); err != nil {
+			t.Errorf("Expected a small body to be sent (streaming %v), got %v", streaming, err)
+		}
+	}
+}
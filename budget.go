@@ -0,0 +1,44 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"time"
+)
+
+// callBudget divides the time left to a helper between the successive calls it makes,
+// so that the helper as a whole completes within its deadline: the deadline of the
+// caller's context or, when it has none, the default request timeout (see
+// WithDefaultRequestTimeout) applied once to the whole helper rather than to each call.
+//
+// Every call gets an equal share of the time left when it starts, the time saved by
+// fast calls being available to the following ones. Concurrent calls are one step of
+// the budget and share its context.
+type callBudget struct {
+	deadline    time.Time
+	hasDeadline bool
+	steps       int
+}
+
+// newCallBudget creates a budget for a helper making steps successive calls
+func (c *CompaniesAPIClient) newCallBudget(ctx context.Context, steps int) *callBudget {
+	budget := &callBudget{steps: steps}
+	if deadline, ok := ctx.Deadline(); ok {
+		budget.deadline, budget.hasDeadline = deadline, true
+	} else if timeout := c.baseClient.defaultRequestTimeout; timeout > 0 {
+		budget.deadline, budget.hasDeadline = time.Now().Add(timeout), true
+	}
+	return budget
+}
+
+// next returns the context of the next step, whose deadline is the share of the step
+func (b *callBudget) next(ctx context.Context) (context.Context, context.CancelFunc) {
+	if !b.hasDeadline {
+		return context.WithCancel(ctx)
+	}
+	if b.steps <= 1 {
+		return context.WithDeadline(ctx, b.deadline)
+	}
+	share := time.Until(b.deadline) / time.Duration(b.steps)
+	b.steps--
+	return context.WithTimeout(ctx, share)
+}
@@ -0,0 +1,107 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCallBudget(t *testing.T) {
+	client, err := ApiClient("test-api-key")
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	budget := client.newCallBudget(ctx, 3)
+	first, cancelFirst := budget.next(ctx)
+	defer cancelFirst()
+	if deadline, _ := first.Deadline(); time.Until(deadline) > time.Second+50*time.Millisecond {
+		t.Errorf("Expected the first call to get a third of the budget, got %v", time.Until(deadline))
+	}
+	budget.next(ctx)
+	last, cancelLast := budget.next(ctx)
+	defer cancelLast()
+	if deadline, _ := last.Deadline(); !deadline.Equal(budget.deadline) {
+		t.Errorf("Expected the last call to get the time left, got %v", time.Until(deadline))
+	}
+
+	// Without deadline nor default timeout the calls are unbounded
+	step, cancelStep := client.newCallBudget(context.Background(), 2).next(context.Background())
+	defer cancelStep()
+	if _, ok := step.Deadline(); ok {
+		t.Error("Expected no deadline")
+	}
+}
+
+func TestEnrichCompanyWithinDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	// The default timeout bounds the helper as a whole, retries included
+	client, err := ApiClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithDefaultRequestTimeout(200*time.Millisecond),
+		WithRetry(3),
+		WithRetryOnConnectionErrors(true),
+	)
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	start := time.Now()
+	_, err = client.EnrichCompany(context.Background(), "apple.com")
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected EnrichCompany to stop at its deadline, took %v", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the deadline to be exceeded, got %v", err)
+	}
+}
+
+func TestDeleteListByNameWithinDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delay := 100 * time.Millisecond
+		if r.Method == http.MethodDelete {
+			delay = 2 * time.Second
+		}
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"lists":[{"id":4,"name":"Partners"}],"meta":{"currentPage":1,"lastPage":1}}`))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithDefaultRequestTimeout(400*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	// Each call would otherwise get the whole default timeout
+	start := time.Now()
+	_, err = client.DeleteListByName(context.Background(), "Partners")
+	if elapsed := time.Since(start); elapsed > 480*time.Millisecond {
+		t.Errorf("Expected DeleteListByName to complete within 400ms, took %v", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the deadline to be exceeded, got %v", err)
+	}
+}
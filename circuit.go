@@ -0,0 +1,116 @@
+package thecompaniesapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling the API while the circuit breaker set
+// with WithCircuitBreaker is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// WithCircuitBreaker stops calling the API once threshold consecutive requests failed
+// with a transport error or a 5xx response, failing fast with ErrCircuitOpen instead
+// of waiting for timeouts. After cooldown, a single probe request is let through: the
+// circuit closes if it succeeds and opens again for another cooldown otherwise. Every
+// attempt counts, so retries made by WithRetry stop as soon as the circuit opens.
+// Requests whose context is cancelled or expires are not counted, since the failure
+// is the caller's. While the circuit is open only the probe decides of its state:
+// the outcome of the requests sent before it opened is ignored. The threshold must be
+// at least 1 and the cooldown positive: ApiClient returns an error otherwise.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) BaseClientOption {
+	return func(c *BaseClient) {
+		if threshold < 1 || cooldown <= 0 {
+			c.setOptionError(fmt.Errorf("invalid circuit breaker: threshold %d must be at least 1 and cooldown %v positive", threshold, cooldown))
+			return
+		}
+		breaker := &circuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+		c.middlewares = append(c.middlewares, func(next roundTripFunc) roundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				allowed, probe := breaker.allow()
+				if !allowed {
+					closeRequestBody(req.Body)
+					return nil, ErrCircuitOpen
+				}
+				resp, err := next(req)
+				if err != nil && req.Context().Err() != nil {
+					breaker.release(probe)
+				} else {
+					breaker.record(probe, err == nil && resp.StatusCode < http.StatusInternalServerError)
+				}
+				return resp, err
+			}
+		})
+	}
+}
+
+// circuitBreaker tracks the consecutive failures of the requests of a client
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	open      bool
+	openedAt  time.Time
+	probing   bool
+	now       func() time.Time
+}
+
+// allow reports whether a request may be sent, letting a single probe through once
+// the cooldown of an open circuit elapsed. probe is true for that request.
+func (b *circuitBreaker) allow() (allowed, probe bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.open {
+		return true, false
+	}
+	if b.probing || b.now().Sub(b.openedAt) < b.cooldown {
+		return false, false
+	}
+	b.probing = true
+	return true, true
+}
+
+// record updates the circuit with the outcome of a request
+func (b *circuitBreaker) record(probe, success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.open {
+		// Requests sent before the circuit opened do not decide of its state
+		if !probe {
+			return
+		}
+		b.probing = false
+		if success {
+			b.failures, b.open = 0, false
+		} else {
+			b.openedAt = b.now()
+		}
+		return
+	}
+	if success {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.open = true
+		b.openedAt = b.now()
+	}
+}
+
+// release lets another probe through when the probe ended without an outcome, its
+// context being done
+func (b *circuitBreaker) release(probe bool) {
+	if !probe {
+		return
+	}
+	b.mu.Lock()
+	b.probing = false
+	b.mu.Unlock()
+}
@@ -0,0 +1,141 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithCircuitBreaker(t *testing.T) {
+	healthy := false
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		if !healthy {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	cooldown := 50 * time.Millisecond
+	client := NewBaseClient("test-api-key", WithCustomBaseURL(server.URL), WithCircuitBreaker(3, cooldown))
+	ctx := context.Background()
+
+	// Consecutive failures open the circuit
+	for i := 0; i < 3; i++ {
+		if _, err := client.MakeRequest(ctx, "GET", "/v2/user", nil); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("Expected request %d to reach the failing API, got %v", i, err)
+		}
+	}
+	if _, err := client.MakeRequest(ctx, "GET", "/v2/user", nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("Expected the open circuit to fail fast, got %d requests", requests)
+	}
+
+	// A failed probe opens the circuit for another cooldown
+	time.Sleep(cooldown)
+	if _, err := client.MakeRequest(ctx, "GET", "/v2/user", nil); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected the probe to reach the API, got %v", err)
+	}
+	if _, err := client.MakeRequest(ctx, "GET", "/v2/user", nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected the circuit to open again, got %v", err)
+	}
+
+	// A successful probe closes the circuit
+	healthy = true
+	time.Sleep(cooldown)
+	for i := 0; i < 2; i++ {
+		if _, err := client.MakeRequest(ctx, "GET", "/v2/user", nil); err != nil {
+			t.Fatalf("Expected the circuit to recover, got %v", err)
+		}
+	}
+	if requests != 6 {
+		t.Errorf("Expected 6 requests, got %d", requests)
+	}
+}
+
+func TestCircuitBreakerCancellation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/slow" {
+			<-r.Context().Done()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewBaseClient("test-api-key", WithCustomBaseURL(server.URL), WithCircuitBreaker(2, time.Minute))
+
+	// Requests cancelled by their caller are not failures of the API
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		_, err := client.MakeRequest(ctx, "GET", "/v2/slow", nil)
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+		}
+	}
+	if _, err := client.MakeRequest(context.Background(), "GET", "/v2/user", nil); err != nil {
+		t.Errorf("Expected the circuit to stay closed, got %v", err)
+	}
+}
+
+func TestCircuitBreakerProbe(t *testing.T) {
+	now := time.Now()
+	breaker := &circuitBreaker{threshold: 1, cooldown: time.Minute, now: func() time.Time { return now }}
+
+	// A request sent before the circuit opens
+	if allowed, probe := breaker.allow(); !allowed || probe {
+		t.Fatalf("Expected a closed circuit, got %v %v", allowed, probe)
+	}
+	breaker.record(false, false)
+	if allowed, _ := breaker.allow(); allowed {
+		t.Fatal("Expected the circuit to open")
+	}
+
+	now = now.Add(time.Minute)
+	if allowed, probe := breaker.allow(); !allowed || !probe {
+		t.Fatalf("Expected a probe after the cooldown, got %v %v", allowed, probe)
+	}
+	if allowed, _ := breaker.allow(); allowed {
+		t.Error("Expected a single probe")
+	}
+
+	// The success of a request admitted before the circuit opened does not close it
+	breaker.record(false, true)
+	if allowed, _ := breaker.allow(); allowed {
+		t.Error("Expected the stale success to be ignored")
+	}
+
+	// A cancelled probe lets another one through, whose success closes the circuit
+	breaker.release(true)
+	if allowed, probe := breaker.allow(); !allowed || !probe {
+		t.Fatalf("Expected another probe, got %v %v", allowed, probe)
+	}
+	breaker.record(true, true)
+	if allowed, probe := breaker.allow(); !allowed || probe {
+		t.Errorf("Expected the circuit to close, got %v %v", allowed, probe)
+	}
+}
+
+func TestWithCircuitBreakerValidation(t *testing.T) {
+	for _, tt := range []struct {
+		threshold int
+		cooldown  time.Duration
+	}{{0, time.Second}, {-1, time.Second}, {3, 0}, {3, -time.Second}} {
+		if _, err := ApiClient("test-api-key", WithCircuitBreaker(tt.threshold, tt.cooldown)); err == nil {
+			t.Errorf("Expected an error for a threshold of %d and a cooldown of %v", tt.threshold, tt.cooldown)
+		}
+	}
+	if _, err := ApiClient("test-api-key", WithCircuitBreaker(1, time.Millisecond)); err != nil {
+		t.Errorf("Expected a valid circuit breaker, got %v", err)
+	}
+}
@@ -1,17 +1,19 @@
 package thecompaniesapi
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 const (
@@ -21,12 +23,52 @@ const (
 	DefaultTimeout = 300 * time.Second
 )
 
+// roundTripFunc executes a prepared request
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+// middleware wraps the execution of requests sent through Do, the first registered
+// middleware being the outermost
+type middleware func(next roundTripFunc) roundTripFunc
+
 // BaseClient represents The Companies API client foundation
+//
+// A BaseClient is safe for concurrent use once created. Options are only applied by
+// NewBaseClient and any state mutated while serving requests is guarded internally.
 type BaseClient struct {
 	baseURL    string
+	basePath   string
+	optionErr  error
 	apiKey     string
 	httpClient *http.Client
 	visitorID  string // Added for visitor ID support
+	apiVersion string
+	locale     string
+
+	minTLSVersion      uint16
+	insecureSkipVerify bool
+	forceHTTP1         bool
+	checkRedirect      func(req *http.Request, via []*http.Request) error
+	streamRequestBody  bool
+	requestBodyLimit   int64
+	tokens             *tokenCache
+	retry              *retryPolicy
+	clientTrace        func(req *http.Request) *httptrace.ClientTrace
+	middlewares        []middleware
+	requestMutators    []func(req *http.Request) error
+	logSampling        *float64
+	logJSONIndent      *string
+	slowThreshold      time.Duration
+	dedup              *requestDeduplicator
+	coalescer          *countCoalescer
+	errorMapper        func(status int, body []byte) error
+	warnings           warningRecorder
+	credits            creditsRecorder
+	trackCredits       bool
+	queryArrayFormat   QueryArrayFormat
+	baseContext        context.Context
+	observer           *Observer
+
+	defaultRequestTimeout time.Duration
 }
 
 // BaseClientOption is a function type for configuring the client
@@ -60,6 +102,41 @@ func WithVisitorID(visitorID string) BaseClientOption {
 	}
 }
 
+// WithAPIVersion pins the API behavior version sent in the Tca-Api-Version header.
+// By default no version is sent and the latest API behavior applies.
+func WithAPIVersion(version string) BaseClientOption {
+	return func(c *BaseClient) {
+		c.apiVersion = version
+	}
+}
+
+// WithLocale sets the Accept-Language header of every request to lang (e.g. "fr" or
+// "fr-FR, en;q=0.8") to receive localized content, such as descriptions and location
+// names, where the API has it. By default no language is requested. A header set on a
+// request with a RequestEditorFn takes precedence.
+func WithLocale(lang string) BaseClientOption {
+	return func(c *BaseClient) {
+		c.locale = lang
+	}
+}
+
+// WithClientTrace registers a factory invoked for every outgoing request whose
+// returned httptrace.ClientTrace hooks (DNS, connect, TLS, first byte...) are
+// attached to the request context. Returning nil skips tracing for that request.
+func WithClientTrace(newTrace func(req *http.Request) *httptrace.ClientTrace) BaseClientOption {
+	return func(c *BaseClient) {
+		c.clientTrace = newTrace
+	}
+}
+
+// setOptionError records the error of an option that could not be applied, the first
+// one being kept
+func (c *BaseClient) setOptionError(err error) {
+	if c.optionErr == nil {
+		c.optionErr = err
+	}
+}
+
 // NewBaseClient creates a new Companies API client
 func NewBaseClient(apiKey string, options ...BaseClientOption) *BaseClient {
 	client := &BaseClient{
@@ -73,6 +150,9 @@ func NewBaseClient(apiKey string, options ...BaseClientOption) *BaseClient {
 	for _, option := range options {
 		option(client)
 	}
+	client.basePath = baseURLPath(client.baseURL)
+	client.applyTransportConfig()
+	client.applyRedirectPolicy()
 
 	return client
 }
@@ -82,6 +162,9 @@ type Error struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 	Details string `json:"details,omitempty"`
+	// StatusCode is the HTTP status of the response carrying the error, 0 when the
+	// error was not built from a response
+	StatusCode int `json:"-"`
 }
 
 func (e *Error) Error() string {
@@ -91,8 +174,98 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("%s: %s", e.Code, e.Message)
 }
 
+// errorCodeStatuses maps well-known error codes to the HTTP status they denote
+var errorCodeStatuses = map[string]int{
+	"bad_request":          http.StatusBadRequest,
+	"invalid_request":      http.StatusBadRequest,
+	"validation_error":     http.StatusUnprocessableEntity,
+	"unauthorized":         http.StatusUnauthorized,
+	"payment_required":     http.StatusPaymentRequired,
+	"insufficient_credits": http.StatusPaymentRequired,
+	"forbidden":            http.StatusForbidden,
+	"not_found":            http.StatusNotFound,
+	"conflict":             http.StatusConflict,
+	"rate_limited":         http.StatusTooManyRequests,
+	"too_many_requests":    http.StatusTooManyRequests,
+	"service_unavailable":  http.StatusServiceUnavailable,
+}
+
+// HTTPStatus returns the HTTP status to use when forwarding the error: the status of
+// the API response when known, otherwise a status derived from Code, defaulting to
+// 500 Internal Server Error
+func (e *Error) HTTPStatus() int {
+	if e.StatusCode != 0 {
+		return e.StatusCode
+	}
+	if status, ok := errorCodeStatuses[strings.ToLower(e.Code)]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// ToJSON encodes the error as a JSON body including its HTTP status, for services
+// forwarding API errors to their own clients
+func (e *Error) ToJSON() []byte {
+	body, _ := json.Marshal(struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Details string `json:"details,omitempty"`
+		Status  int    `json:"status"`
+	}{e.Code, e.Message, e.Details, e.HTTPStatus()})
+	return body
+}
+
+// maxErrorSnippetLength bounds the response body excerpt kept by HTTPError
+const maxErrorSnippetLength = 200
+
+// HTTPError is returned for unsuccessful responses whose body is not a JSON API error,
+// such as the HTML error pages served by proxies and gateways
+type HTTPError struct {
+	StatusCode  int
+	ContentType string
+	// Snippet is the beginning of the response body with its whitespace collapsed
+	Snippet string
+}
+
+func (e *HTTPError) Error() string {
+	if e.Snippet == "" {
+		return fmt.Sprintf("HTTP %d", e.StatusCode)
+	}
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Snippet)
+}
+
+// newHTTPError builds an HTTPError with a truncated excerpt of the body
+func newHTTPError(resp *http.Response, body []byte) *HTTPError {
+	snippet := strings.Join(strings.Fields(string(body)), " ")
+	if len(snippet) > maxErrorSnippetLength {
+		cut := maxErrorSnippetLength
+		for cut > 0 && !utf8.RuneStart(snippet[cut]) {
+			cut--
+		}
+		snippet = snippet[:cut] + "..."
+	}
+	return &HTTPError{
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Snippet:     snippet,
+	}
+}
+
+// isJSONContentType reports whether a Content-Type header denotes a JSON document.
+// An empty Content-Type is accepted since some servers omit it.
+func isJSONContentType(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
 // BuildQueryString serializes query parameters
-// - Objects and arrays are JSON stringified then URL encoded
+// - Objects and arrays are JSON stringified then URL encoded (see WithQueryArrayFormat)
 // - Primitives are converted to strings
 func (c *BaseClient) BuildQueryString(params map[string]interface{}) string {
 	if len(params) == 0 {
@@ -106,6 +279,13 @@ func (c *BaseClient) BuildQueryString(params map[string]interface{}) string {
 			continue
 		}
 
+		if encoded, ok := c.encodeQueryArray(key, value); ok {
+			if encoded != "" {
+				parts = append(parts, encoded)
+			}
+			continue
+		}
+
 		encodedKey := url.QueryEscape(key)
 		var encodedValue string
 
@@ -180,70 +360,278 @@ func (c *BaseClient) BuildQueryString(params map[string]interface{}) string {
 	return strings.Join(parts, "&")
 }
 
-// MakeRequestWithQuery performs an HTTP request with query parameters serialized
+// MakeRequestWithQuery performs an HTTP request with query parameters serialized.
+// Parameters already present in the query of path are overridden by the queryParams
+// of the same name, so that every parameter is sent once.
 func (c *BaseClient) MakeRequestWithQuery(ctx context.Context, method, path string, queryParams map[string]interface{}, body any) ([]byte, error) {
 	fullPath := path
 	if len(queryParams) > 0 {
 		queryString := c.BuildQueryString(queryParams)
 		if queryString != "" {
-			separator := "?"
-			if strings.Contains(path, "?") {
-				separator = "&"
+			base, rawQuery, _ := strings.Cut(path, "?")
+			if rawQuery = withoutQueryParams(rawQuery, queryParams); rawQuery != "" {
+				queryString = rawQuery + "&" + queryString
 			}
-			fullPath = path + separator + queryString
+			fullPath = base + "?" + queryString
 		}
 	}
 
 	return c.MakeRequest(ctx, method, fullPath, body)
 }
 
-// MakeRequest performs an HTTP request with authentication and returns the response body
-func (c *BaseClient) MakeRequest(ctx context.Context, method, path string, body any) ([]byte, error) {
-	var reqBody io.Reader
-	if body != nil {
-		jsonBody, err := json.Marshal(body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+// withoutQueryParams removes from a raw query the parameters set in params, keeping
+// the other parameters in their order
+func withoutQueryParams(rawQuery string, params map[string]interface{}) string {
+	if rawQuery == "" {
+		return ""
+	}
+	var kept []string
+	for _, part := range strings.Split(rawQuery, "&") {
+		key, _, _ := strings.Cut(part, "=")
+		if unescaped, err := url.QueryUnescape(key); err == nil {
+			key = unescaped
 		}
-		reqBody = bytes.NewBuffer(jsonBody)
+		if value, ok := params[key]; ok && !isNilQueryValue(value) {
+			continue
+		}
+		kept = append(kept, part)
+	}
+	return strings.Join(kept, "&")
+}
+
+// isNilQueryValue reports whether BuildQueryString skips a parameter value
+func isNilQueryValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+// MakeRequest performs an HTTP request with authentication and returns the response body.
+// HEAD requests return a nil body on success and an *HTTPError carrying the status code
+// otherwise.
+func (c *BaseClient) MakeRequest(ctx context.Context, method, path string, body any) ([]byte, error) {
+	return c.makeRequestWithEditors(ctx, method, path, body, nil)
+}
+
+// makeRequestWithEditors performs a request like MakeRequest, applying the request
+// editors before sending it
+func (c *BaseClient) makeRequestWithEditors(ctx context.Context, method, path string, body any, reqEditors []RequestEditorFn) ([]byte, error) {
+	requestURL, err := c.requestURL(path)
+	if err != nil {
+		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	reqBody, getBody, err := c.requestBody(body)
 	if err != nil {
+		return nil, err
+	}
+
+	ctx = c.requestContext(ctx)
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, reqBody)
+	if err != nil {
+		closeRequestBody(reqBody)
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Basic "+c.apiKey)
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
-
-	if c.visitorID != "" {
-		req.Header.Set("Tca-Visitor-Id", c.visitorID)
+	if getBody != nil {
+		req.GetBody = getBody
+	}
+	for _, editor := range reqEditors {
+		if err := editor(ctx, req); err != nil {
+			closeRequestBody(reqBody)
+			return nil, err
+		}
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	responseBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+	// HEAD responses carry no body: the outcome is conveyed by the status code only
+	var responseBody []byte
+	if method != http.MethodHead {
+		responseBody, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
 	}
 
 	if resp.StatusCode >= 400 {
-		var apiErr Error
-		if err := json.Unmarshal(responseBody, &apiErr); err != nil {
-			return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(responseBody))
-		}
-		return nil, &apiErr
+		return nil, c.responseError(resp, responseBody)
 	}
 
 	return responseBody, nil
 }
 
+// requestURL joins the base URL and path with exactly one slash between them
+func (c *BaseClient) requestURL(path string) (string, error) {
+	if err := validateBaseURL(c.baseURL); err != nil {
+		return "", err
+	}
+	base := strings.TrimRight(c.baseURL, "/")
+	if path == "" {
+		return base, nil
+	}
+	return base + "/" + strings.TrimLeft(path, "/"), nil
+}
+
+// validateBaseURL ensures the base URL is absolute, with a scheme and a host
+func validateBaseURL(baseURL string) error {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return fmt.Errorf("invalid base URL %q: %w", baseURL, err)
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return fmt.Errorf("invalid base URL %q: a scheme and host are required (e.g. https://api.thecompaniesapi.com)", baseURL)
+	}
+	return nil
+}
+
+// WithErrorMapper translates unsuccessful responses (status >= 400) into the errors
+// of the application. The mapper receives the status and raw body of the response;
+// when it returns nil the default *Error or *HTTPError is returned instead.
+func WithErrorMapper(mapper func(status int, body []byte) error) BaseClientOption {
+	return func(c *BaseClient) {
+		c.errorMapper = mapper
+	}
+}
+
+// responseError builds the error returned for an unsuccessful response
+func (c *BaseClient) responseError(resp *http.Response, body []byte) error {
+	if c.errorMapper != nil {
+		if err := c.errorMapper(resp.StatusCode, body); err != nil {
+			return err
+		}
+	}
+	err := decodeResponseError(resp, body)
+	if isMaintenanceResponse(resp.StatusCode, body) {
+		return fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+	}
+	if isCompanyNotFound(resp) {
+		return fmt.Errorf("%w: %w", ErrCompanyNotFound, err)
+	}
+	return err
+}
+
+// decodeResponseError decodes the API error of a response, falling back to an
+// *HTTPError for bodies that are not JSON API errors
+func decodeResponseError(resp *http.Response, body []byte) error {
+	if !isJSONContentType(resp.Header.Get("Content-Type")) {
+		return newHTTPError(resp, body)
+	}
+	var apiErr Error
+	if err := json.Unmarshal(body, &apiErr); err != nil {
+		return newHTTPError(resp, body)
+	}
+	apiErr.StatusCode = resp.StatusCode
+	return &apiErr
+}
+
+// Do sends an HTTP request through the client pipeline: it sets the authentication
+// (static API key or token provider) and visitor headers, attaches the configured
+// client trace and executes the request with the underlying HTTP client, retrying it
+// when WithRetry is enabled and sharing concurrent identical GET requests when
+// WithRequestDeduplication is. Generated operations are routed through Do as well, so
+// every option applies to both MakeRequest and the typed methods. Do fails without
+// sending when an option of the client could not be applied.
+func (c *BaseClient) Do(req *http.Request) (*http.Response, error) {
+	if c.optionErr != nil {
+		closeRequestBody(req.Body)
+		return nil, c.optionErr
+	}
+	req, cancel := c.withDefaultDeadline(c.withRequestContext(c.withBasePath(req)))
+	if cancel == nil {
+		return c.doRequest(req)
+	}
+
+	resp, err := c.doRequest(req)
+	if err != nil {
+		cancel()
+		return resp, err
+	}
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// doRequest sends a request, sharing it with concurrent identical GET requests when
+// deduplication is enabled and with the identical count requests of the window when
+// count coalescing is enabled
+func (c *BaseClient) doRequest(req *http.Request) (*http.Response, error) {
+	if c.coalescer != nil && c.coalescer.coalesces(req) {
+		return c.coalescer.do(req, c.doOnce)
+	}
+	if c.dedup != nil && req.Method == http.MethodGet {
+		return c.doShared(req, c.doOnce)
+	}
+	return c.doOnce(req)
+}
+
+// doOnce sends a request through the retry policy, if any
+func (c *BaseClient) doOnce(req *http.Request) (*http.Response, error) {
+	if c.retry != nil && c.retry.maxAttempts > 1 {
+		return c.retry.do(req, c.send)
+	}
+	return c.send(req)
+}
+
+// send performs a single attempt of a request
+func (c *BaseClient) send(req *http.Request) (*http.Response, error) {
+	apiKey := c.apiKey
+	if c.tokens != nil {
+		token, err := c.tokens.get(req.Context())
+		if err != nil {
+			closeRequestBody(req.Body)
+			return nil, fmt.Errorf("failed to obtain API token: %w", err)
+		}
+		apiKey = token
+	}
+
+	req.Header.Set("Authorization", "Basic "+apiKey)
+	if c.visitorID != "" && req.Header.Get("Tca-Visitor-Id") == "" {
+		req.Header.Set("Tca-Visitor-Id", c.visitorID)
+	}
+	if c.apiVersion != "" {
+		req.Header.Set("Tca-Api-Version", c.apiVersion)
+	}
+	if c.locale != "" && req.Header.Get("Accept-Language") == "" {
+		req.Header.Set("Accept-Language", c.locale)
+	}
+
+	if c.clientTrace != nil {
+		if trace := c.clientTrace(req); trace != nil {
+			req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+		}
+	}
+	req = c.observeConnection(req)
+
+	send := c.mutateRequest(c.httpClient.Do)
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		send = c.middlewares[i](send)
+	}
+
+	start := time.Now()
+	resp, err := send(req)
+	c.observeDuration(req, time.Since(start))
+	if err != nil {
+		return resp, err
+	}
+	if c.tokens != nil && resp.StatusCode == http.StatusUnauthorized {
+		c.tokens.invalidate(apiKey)
+	}
+	c.warnings.record(resp)
+	if err := c.recordCredits(req, resp); err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return resp, nil
+}
+
 // BaseURL returns the configured base URL
 func (c *BaseClient) BaseURL() string {
 	return c.baseURL
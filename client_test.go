@@ -2,7 +2,13 @@ package thecompaniesapi
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"net/url"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -204,7 +210,7 @@ func TestMakeRequestWithQuery(t *testing.T) {
 	}
 
 	_, err := client.MakeRequestWithQuery(ctx, "GET", "/v2/companies", queryParams, nil)
-	
+
 	// We expect this to fail since we don't have a real API key,
 	// but it should fail with a proper error, not a panic
 	if err == nil {
@@ -224,7 +230,7 @@ func TestQueryStringWithExistingParams(t *testing.T) {
 	}
 
 	_, err := client.MakeRequestWithQuery(ctx, "GET", "/v2/companies?search=test", queryParams, nil)
-	
+
 	// We expect this to fail since we don't have a real API key,
 	// but it should construct the URL properly with & separator
 	if err == nil {
@@ -234,3 +240,293 @@ func TestQueryStringWithExistingParams(t *testing.T) {
 	}
 }
 
+func TestClientTrace(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"healthy":true}`))
+	}))
+	defer server.Close()
+
+	var gotConn, wroteRequest, firstByte atomic.Int32
+	client := NewBaseClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithClientTrace(func(req *http.Request) *httptrace.ClientTrace {
+			return &httptrace.ClientTrace{
+				GotConn:              func(httptrace.GotConnInfo) { gotConn.Add(1) },
+				WroteRequest:         func(httptrace.WroteRequestInfo) { wroteRequest.Add(1) },
+				GotFirstResponseByte: func() { firstByte.Add(1) },
+			}
+		}),
+	)
+
+	if _, err := client.MakeRequest(context.Background(), "GET", "/", nil); err != nil {
+		t.Fatalf("MakeRequest failed: %v", err)
+	}
+
+	if gotConn.Load() != 1 || wroteRequest.Load() != 1 || firstByte.Load() != 1 {
+		t.Errorf("Expected each trace callback to fire once, got GotConn=%d WroteRequest=%d GotFirstResponseByte=%d",
+			gotConn.Load(), wroteRequest.Load(), firstByte.Load())
+	}
+}
+
+func TestAPIVersionHeader(t *testing.T) {
+	var versions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		versions = append(versions, r.Header.Get("Tca-Api-Version"))
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	if _, err := NewBaseClient("test-api-key", WithCustomBaseURL(server.URL)).MakeRequest(ctx, "GET", "/", nil); err != nil {
+		t.Fatalf("MakeRequest failed: %v", err)
+	}
+	if _, err := NewBaseClient("test-api-key", WithCustomBaseURL(server.URL), WithAPIVersion("2025-01-01")).MakeRequest(ctx, "GET", "/", nil); err != nil {
+		t.Fatalf("MakeRequest failed: %v", err)
+	}
+
+	if len(versions) != 2 || versions[0] != "" || versions[1] != "2025-01-01" {
+		t.Errorf("Expected no version then 2025-01-01, got %q", versions)
+	}
+}
+
+func TestLocaleHeader(t *testing.T) {
+	var languages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		languages = append(languages, r.Header.Get("Accept-Language"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	if _, err := NewBaseClient("test-api-key", WithCustomBaseURL(server.URL)).MakeRequest(ctx, "GET", "/", nil); err != nil {
+		t.Fatalf("MakeRequest failed: %v", err)
+	}
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL), WithLocale("fr-FR"))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+	if _, err := client.FetchCompany(ctx, "apple.com", nil); err != nil {
+		t.Fatalf("FetchCompany failed: %v", err)
+	}
+	// A per-request header takes precedence
+	if _, err := client.FetchCompany(ctx, "apple.com", nil, func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Accept-Language", "de")
+		return nil
+	}); err != nil {
+		t.Fatalf("FetchCompany failed: %v", err)
+	}
+
+	if len(languages) != 3 || languages[0] != "" || languages[1] != "fr-FR" || languages[2] != "de" {
+		t.Errorf("Expected no language, fr-FR then de, got %q", languages)
+	}
+}
+
+func TestRequestURLJoining(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.RequestURI())
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		baseURL  string
+		path     string
+		expected string
+	}{
+		{server.URL, "/v2/companies", "/v2/companies"},
+		{server.URL + "/", "/v2/companies", "/v2/companies"},
+		{server.URL + "//", "v2/companies?page=2", "/v2/companies?page=2"},
+		{server.URL + "/proxy/", "//v2/companies", "/proxy/v2/companies"},
+	}
+
+	for _, tt := range tests {
+		paths = nil
+		client := NewBaseClient("test-api-key", WithCustomBaseURL(tt.baseURL))
+		if _, err := client.MakeRequest(context.Background(), "GET", tt.path, nil); err != nil {
+			t.Fatalf("MakeRequest(%s, %s) failed: %v", tt.baseURL, tt.path, err)
+		}
+		if len(paths) != 1 || paths[0] != tt.expected {
+			t.Errorf("MakeRequest(%s, %s) requested %v, expected %s", tt.baseURL, tt.path, paths, tt.expected)
+		}
+	}
+
+	paths = nil
+	apiClient, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL+"//"))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+	if _, err := apiClient.FetchCompany(context.Background(), "apple.com", nil); err != nil {
+		t.Fatalf("FetchCompany failed: %v", err)
+	}
+	if len(paths) != 1 || paths[0] != "/v2/companies/apple.com" {
+		t.Errorf("Expected generated operations to avoid double slashes, requested %v", paths)
+	}
+}
+
+func TestInvalidBaseURL(t *testing.T) {
+	client := NewBaseClient("test-api-key", WithCustomBaseURL("api.thecompaniesapi.com"))
+	_, err := client.MakeRequest(context.Background(), "GET", "/v2/companies", nil)
+	if err == nil || !strings.Contains(err.Error(), "scheme and host are required") {
+		t.Errorf("Expected a missing scheme error, got %v", err)
+	}
+
+	if _, err := ApiClient("test-api-key", WithCustomBaseURL("api.thecompaniesapi.com")); err == nil {
+		t.Error("Expected ApiClient to reject a base URL without scheme")
+	}
+}
+
+func TestHTMLErrorPage(t *testing.T) {
+	page := "<html>\n<head><title>502 Bad Gateway</title></head>\n<body>" + strings.Repeat("<p>upstream unavailable</p>", 50) + "</body>\n</html>"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	client := NewBaseClient("test-api-key", WithCustomBaseURL(server.URL))
+	_, err := client.MakeRequest(context.Background(), "GET", "/v2/health", nil)
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("Expected *HTTPError, got %T: %v", err, err)
+	}
+	if httpErr.StatusCode != http.StatusBadGateway || httpErr.ContentType != "text/html; charset=utf-8" {
+		t.Errorf("Unexpected error details: %+v", httpErr)
+	}
+	if !strings.HasPrefix(httpErr.Snippet, "<html> <head><title>502 Bad Gateway</title>") {
+		t.Errorf("Unexpected snippet: %s", httpErr.Snippet)
+	}
+	if len(httpErr.Snippet) > maxErrorSnippetLength+len("...") {
+		t.Errorf("Expected snippet to be truncated, got %d bytes", len(httpErr.Snippet))
+	}
+
+	// JSON errors are still decoded as API errors
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":"not_found","message":"Company not found"}`))
+	})
+	_, err = client.MakeRequest(context.Background(), "GET", "/v2/companies/unknown.com", nil)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Code != "not_found" {
+		t.Errorf("Expected API error, got %T: %v", err, err)
+	}
+}
+
+func TestErrorHTTPStatus(t *testing.T) {
+	cases := []struct {
+		err      *Error
+		expected int
+	}{
+		{&Error{Code: "not_found"}, http.StatusNotFound},
+		{&Error{Code: "UNAUTHORIZED"}, http.StatusUnauthorized},
+		{&Error{Code: "rate_limited"}, http.StatusTooManyRequests},
+		{&Error{Code: "insufficient_credits"}, http.StatusPaymentRequired},
+		{&Error{Code: "something_unexpected"}, http.StatusInternalServerError},
+		// The status of the response takes precedence over the code
+		{&Error{Code: "not_found", StatusCode: http.StatusGone}, http.StatusGone},
+	}
+	for _, tc := range cases {
+		if status := tc.err.HTTPStatus(); status != tc.expected {
+			t.Errorf("Expected status %d for %+v, got %d", tc.expected, tc.err, status)
+		}
+	}
+
+	body := (&Error{Code: "not_found", Message: "Company not found"}).ToJSON()
+	if string(body) != `{"code":"not_found","message":"Company not found","status":404}` {
+		t.Errorf("Unexpected JSON body: %s", body)
+	}
+}
+
+func TestErrorStatusCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"code":"forbidden","message":"Plan required"}`))
+	}))
+	defer server.Close()
+
+	client := NewBaseClient("test-api-key", WithCustomBaseURL(server.URL))
+	_, err := client.MakeRequest(context.Background(), "GET", "/v2/lists", nil)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden || apiErr.HTTPStatus() != http.StatusForbidden {
+		t.Errorf("Expected a 403 API error, got %v", err)
+	}
+}
+
+func TestWithErrorMapper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v2/quota":
+			w.WriteHeader(http.StatusPaymentRequired)
+			w.Write([]byte(`{"code":"insufficient_credits","message":"No credits left"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":"not_found","message":"Not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	errOutOfCredits := errors.New("out of credits")
+	var statuses []int
+	var bodies []string
+	client := NewBaseClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithErrorMapper(func(status int, body []byte) error {
+			statuses = append(statuses, status)
+			bodies = append(bodies, string(body))
+			if status == http.StatusPaymentRequired {
+				return errOutOfCredits
+			}
+			return nil
+		}),
+	)
+
+	if _, err := client.MakeRequest(context.Background(), "GET", "/v2/quota", nil); !errors.Is(err, errOutOfCredits) {
+		t.Errorf("Expected the mapped error, got %v", err)
+	}
+	// A nil mapping falls back to the API error
+	_, err := client.MakeRequest(context.Background(), "GET", "/v2/unknown", nil)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Code != "not_found" {
+		t.Errorf("Expected the default API error, got %v", err)
+	}
+
+	if len(statuses) != 2 || statuses[0] != http.StatusPaymentRequired || statuses[1] != http.StatusNotFound {
+		t.Errorf("Unexpected statuses passed to the mapper: %v", statuses)
+	}
+	if bodies[0] != `{"code":"insufficient_credits","message":"No credits left"}` {
+		t.Errorf("Unexpected body passed to the mapper: %s", bodies[0])
+	}
+}
+
+func TestMakeRequestWithQueryOverridesPathParams(t *testing.T) {
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewBaseClient("test-api-key", WithCustomBaseURL(server.URL))
+	ctx := context.Background()
+
+	var nilSize *int
+	queryParams := map[string]interface{}{"search": "saas", "page": 2, "size": nilSize}
+	if _, err := client.MakeRequestWithQuery(ctx, "GET", "/v2/companies?search=test&simplified=true&size=5", queryParams, nil); err != nil {
+		t.Fatalf("MakeRequestWithQuery failed: %v", err)
+	}
+	query := queries[0]
+	if len(query["search"]) != 1 || query.Get("search") != "saas" {
+		t.Errorf("Expected the params map to override search, got %v", query["search"])
+	}
+	if query.Get("simplified") != "true" || query.Get("page") != "2" || query.Get("size") != "5" {
+		t.Errorf("Expected the other params to be kept, got %v", query)
+	}
+}
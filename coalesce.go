@@ -0,0 +1,107 @@
+package thecompaniesapi
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// countOperations are the operations whose requests are coalesced by WithCountCoalescing
+var countOperations = map[string]bool{
+	"CountCompanies":     true,
+	"CountCompaniesPost": true,
+}
+
+// WithCountCoalescing makes identical CountCompanies and CountCompaniesPost requests
+// sent within window share a single API call and result, for dashboards firing the
+// same counts many times in a row. Concurrent identical requests share the in-flight
+// call as with WithRequestDeduplication, and its successful response is then served
+// from memory to the identical requests of the following window (e.g. 100ms).
+// Requests are identical when their URL, headers and body match. The shared call runs
+// with the context of the first caller.
+func WithCountCoalescing(window time.Duration) BaseClientOption {
+	return func(c *BaseClient) {
+		c.coalescer = &countCoalescer{window: window, results: map[string]coalescedResult{}}
+	}
+}
+
+// countCoalescer shares the responses of identical count requests during a window
+type countCoalescer struct {
+	window time.Duration
+	group  singleflight.Group
+
+	mu      sync.Mutex
+	results map[string]coalescedResult
+}
+
+// coalescedResult is a response served until its expiry
+type coalescedResult struct {
+	shared  *sharedResponse
+	expires time.Time
+}
+
+// coalesces reports whether the request is coalesced
+func (c *countCoalescer) coalesces(req *http.Request) bool {
+	return countOperations[requestOperationName(req)]
+}
+
+// do sends the request once for all the identical requests of the window
+func (c *countCoalescer) do(req *http.Request, send roundTripFunc) (*http.Response, error) {
+	key := deduplicationKey(req)
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := readRequestBody(req)
+		if err != nil {
+			return nil, err
+		}
+		key += "\n\n" + string(body)
+	}
+
+	if shared, ok := c.lookup(key); ok {
+		closeRequestBody(req.Body)
+		return shared.copy(), nil
+	}
+	value, err, _ := c.group.Do(key, func() (interface{}, error) {
+		resp, err := send(req)
+		if err != nil {
+			return nil, err
+		}
+		shared, err := bufferResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusOK {
+			c.store(key, shared)
+		}
+		return shared, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*sharedResponse).copy(), nil
+}
+
+// lookup returns the unexpired response stored for key
+func (c *countCoalescer) lookup(key string) (*sharedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result, ok := c.results[key]
+	if !ok || !time.Now().Before(result.expires) {
+		return nil, false
+	}
+	return result.shared, true
+}
+
+// store keeps the response of key for the window, dropping the expired ones
+func (c *countCoalescer) store(key string, shared *sharedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for k, result := range c.results {
+		if !now.Before(result.expires) {
+			delete(c.results, k)
+		}
+	}
+	c.results[key] = coalescedResult{shared: shared, expires: now.Add(c.window)}
+}
@@ -0,0 +1,101 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithCountCoalescing(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"count":42}`))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL), WithCountCoalescing(time.Second))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+	search := "saas"
+	count := func(search string) {
+		response, err := client.CountCompaniesPost(context.Background(), CountCompaniesPostJSONRequestBody{Search: &search})
+		if err != nil {
+			t.Errorf("CountCompaniesPost returned error: %v", err)
+			return
+		}
+		if response.StatusCode() != http.StatusOK || string(response.Body) != `{"count":42}` {
+			t.Errorf("Expected every caller to receive the count, got %s", response.Body)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			count(search)
+		}()
+	}
+	wg.Wait()
+	if n := hits.Load(); n != 1 {
+		t.Errorf("Expected a single HTTP request, got %d", n)
+	}
+
+	// The result is reused during the window, for identical bodies only
+	count(search)
+	if n := hits.Load(); n != 1 {
+		t.Errorf("Expected the count to be served from the window, got %d requests", n)
+	}
+	count("fintech")
+	if n := hits.Load(); n != 2 {
+		t.Errorf("Expected a different body to be sent, got %d requests", n)
+	}
+
+	// Other operations are not coalesced
+	client.FetchCompany(context.Background(), "apple.com", nil)
+	client.FetchCompany(context.Background(), "apple.com", nil)
+	if n := hits.Load(); n != 4 {
+		t.Errorf("Expected 2 more requests, got %d", n)
+	}
+}
+
+func TestWithCountCoalescingExpiry(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"count":42}`))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL), WithCountCoalescing(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+	ctx := context.Background()
+
+	// Failed responses are not reused
+	client.CountCompanies(ctx, &CountCompaniesParams{})
+	client.CountCompanies(ctx, &CountCompaniesParams{})
+	client.CountCompanies(ctx, &CountCompaniesParams{})
+	if n := hits.Load(); n != 2 {
+		t.Errorf("Expected the failed count to be sent again, got %d requests", n)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	client.CountCompanies(ctx, &CountCompaniesParams{})
+	if n := hits.Load(); n != 3 {
+		t.Errorf("Expected the count to be sent after the window, got %d requests", n)
+	}
+}
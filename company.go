@@ -0,0 +1,266 @@
+package thecompaniesapi
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Simplified responses
+//
+// Requests made with Simplified set to true return a lighter Company. The OpenAPI
+// specification does not document which fields such responses keep, so any field may
+// be missing. The accessors below return zero values for missing fields, so they are
+// safe to use with both response kinds.
+//
+// The API has no field selection: FetchCompany and SearchCompanies take no fields or
+// attributes parameter, so Simplified is the only way to request a lighter company.
+
+// IsSimplified is a heuristic reporting whether the company may be the result of a
+// simplified request: it only checks that the About or Domain section is set while
+// all of the detailed sections (Analytics, Apps, Assets, Codes, Companies, Contacts,
+// Contents, Finances, People, Secondaries, Technologies, Urls and Vectors) are nil.
+// A full company for which the API knows little can match as well.
+func (c Company) IsSimplified() bool {
+	if c.About == nil && c.Domain == nil {
+		return false
+	}
+	return c.Analytics == nil && c.Apps == nil && c.Assets == nil && c.Codes == nil &&
+		c.Companies == nil && c.Contacts == nil && c.Contents == nil && c.Finances == nil &&
+		c.People == nil && c.Secondaries == nil && c.Technologies == nil && c.Urls == nil &&
+		c.Vectors == nil
+}
+
+// Merge combines the company with other, typically a cached simplified record with
+// the fresh full record of FetchCompanyFull: fields missing from c are filled from
+// other, nested objects being merged field by field, while the fields set in c are
+// kept. Lists are not merged element by element. Neither company is modified.
+func (c Company) Merge(other Company) Company {
+	merged := mergeValues(companyValues(c), companyValues(other))
+	encoded, err := json.Marshal(merged)
+	if err != nil {
+		return c
+	}
+	var result Company
+	if err := json.Unmarshal(encoded, &result); err != nil {
+		return c
+	}
+	return result
+}
+
+// mergeValues merges two JSON values, the fields of preferred taking precedence
+func mergeValues(preferred, fallback any) any {
+	preferredObject, preferredIsObject := preferred.(map[string]any)
+	fallbackObject, fallbackIsObject := fallback.(map[string]any)
+	if !preferredIsObject || !fallbackIsObject {
+		if preferred == nil {
+			return fallback
+		}
+		return preferred
+	}
+
+	merged := make(map[string]any, len(preferredObject)+len(fallbackObject))
+	for key, value := range fallbackObject {
+		merged[key] = value
+	}
+	for key, value := range preferredObject {
+		merged[key] = mergeValues(value, fallbackObject[key])
+	}
+	return merged
+}
+
+// Name returns the name of the company
+func (c Company) Name() string {
+	if c.About == nil {
+		return ""
+	}
+	return stringValue(c.About.Name)
+}
+
+// DomainName returns the domain of the company, e.g. "apple.com"
+func (c Company) DomainName() string {
+	if c.Domain == nil {
+		return ""
+	}
+	return c.Domain.Domain
+}
+
+// Industry returns the main industry of the company
+func (c Company) Industry() string {
+	if c.About == nil {
+		return ""
+	}
+	return stringValue(c.About.Industry)
+}
+
+// Industries returns the industries of the company
+func (c Company) Industries() []string {
+	if c.About == nil || c.About.Industries == nil {
+		return nil
+	}
+	return *c.About.Industries
+}
+
+// EmployeeRange returns the employee count range of the company, e.g. "51-200"
+func (c Company) EmployeeRange() string {
+	if c.About == nil || c.About.TotalEmployees == nil {
+		return ""
+	}
+	return string(*c.About.TotalEmployees)
+}
+
+// EmployeeCount returns the employee count of the company as a numeric range: an exact
+// count yields min == max, a bucket like "51-200" or "1k-5k" its bounds and an
+// open-ended bucket like "over-10k" math.MaxInt as max. ok is false when the count is
+// unknown or cannot be parsed.
+func (c Company) EmployeeCount() (min, max int, ok bool) {
+	value := strings.TrimSpace(c.EmployeeRange())
+	if value == "" {
+		return 0, 0, false
+	}
+	if lower, found := strings.CutPrefix(value, "over-"); found {
+		min, ok = parseEmployeeCount(lower)
+		return min, math.MaxInt, ok
+	}
+	lower, upper, found := strings.Cut(value, "-")
+	if !found {
+		min, ok = parseEmployeeCount(value)
+		return min, min, ok
+	}
+	min, okMin := parseEmployeeCount(lower)
+	max, okMax := parseEmployeeCount(upper)
+	if !okMin || !okMax || min > max {
+		return 0, 0, false
+	}
+	return min, max, true
+}
+
+// parseEmployeeCount parses a count like "200" or "5k"
+func parseEmployeeCount(value string) (int, bool) {
+	multiplier := 1
+	if number, found := strings.CutSuffix(strings.ToLower(value), "k"); found {
+		value, multiplier = number, 1000
+	}
+	count, err := strconv.Atoi(value)
+	if err != nil || count < 0 {
+		return 0, false
+	}
+	return count * multiplier, true
+}
+
+// YearFounded returns the year the company was founded, 0 when unknown
+func (c Company) YearFounded() int {
+	if c.About == nil || c.About.YearFounded == nil {
+		return 0
+	}
+	return int(*c.About.YearFounded)
+}
+
+// Description returns the primary description of the company
+func (c Company) Description() string {
+	if c.Descriptions == nil {
+		return ""
+	}
+	return stringValue(c.Descriptions.Primary)
+}
+
+// CountryCode returns the country code of the headquarters of the company
+func (c Company) CountryCode() string {
+	if c.Locations == nil || c.Locations.Headquarters == nil || c.Locations.Headquarters.Country == nil {
+		return ""
+	}
+	return stringValue(c.Locations.Headquarters.Country.Code)
+}
+
+// CityCode returns the city code of the headquarters of the company
+func (c Company) CityCode() string {
+	if c.Locations == nil || c.Locations.Headquarters == nil || c.Locations.Headquarters.City == nil {
+		return ""
+	}
+	return stringValue(c.Locations.Headquarters.City.Code)
+}
+
+// LinkedInURL returns the URL of the LinkedIn page of the company
+func (c Company) LinkedInURL() string {
+	if c.Socials == nil || c.Socials.Linkedin == nil {
+		return ""
+	}
+	return c.Socials.Linkedin.Url
+}
+
+// LinkedinURL returns the URL of the LinkedIn page of the company.
+//
+// Deprecated: use LinkedInURL.
+func (c Company) LinkedinURL() string {
+	return c.LinkedInURL()
+}
+
+// SocialProfiles returns the URLs of the social media pages of the company keyed by
+// platform, as named by the API ("linkedin", "twitter", "github"...). Platforms without
+// a URL are left out and the map is empty when the company has no social data.
+func (c Company) SocialProfiles() map[string]string {
+	profiles := map[string]string{}
+	if c.Socials == nil {
+		return profiles
+	}
+	encoded, err := json.Marshal(c.Socials)
+	if err != nil {
+		return profiles
+	}
+	var socials map[string]*struct {
+		Url string `json:"url"`
+	}
+	if err := json.Unmarshal(encoded, &socials); err != nil {
+		return profiles
+	}
+	for platform, social := range socials {
+		if social != nil && social.Url != "" {
+			profiles[platform] = social.Url
+		}
+	}
+	return profiles
+}
+
+// Revenue returns the revenue range of the company, "" when unknown
+func (c Company) Revenue() string {
+	if c.Finances == nil || c.Finances.Revenue == nil {
+		return ""
+	}
+	return string(*c.Finances.Revenue)
+}
+
+// ActiveTechnologies returns the technologies used by the company
+func (c Company) ActiveTechnologies() []string {
+	if c.Technologies == nil {
+		return nil
+	}
+	return c.Technologies.Active
+}
+
+// HasTechnology reports whether the company uses a technology, matching name against
+// the active technologies and the slugs of the detected ones case-insensitively
+func (c Company) HasTechnology(name string) bool {
+	if c.Technologies == nil {
+		return false
+	}
+	for _, technology := range c.Technologies.Active {
+		if strings.EqualFold(technology, name) {
+			return true
+		}
+	}
+	for _, detail := range c.Technologies.Details {
+		if strings.EqualFold(detail.Slug, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// stringValue dereferences an optional string
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
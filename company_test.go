@@ -0,0 +1,142 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestSimplifiedCompany(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("simplified") == "true" {
+			w.Write([]byte(`{"about":{"name":"Apple","industry":"consumer-electronics"},"domain":{"domain":"apple.com"}}`))
+			return
+		}
+		w.Write([]byte(`{"about":{"name":"Apple"},"domain":{"domain":"apple.com"},"finances":{"revenue":"over-1b"},"technologies":{"active":["swift"],"categories":[]}}`))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	simplified := true
+	response, err := client.FetchCompany(context.Background(), "apple.com", &FetchCompanyParams{Simplified: &simplified})
+	if err != nil || response.JSON200 == nil {
+		t.Fatalf("FetchCompany returned %v", err)
+	}
+	company := *response.JSON200
+	if !company.IsSimplified() {
+		t.Error("Expected the company to be detected as simplified")
+	}
+	if company.Name() != "Apple" || company.DomainName() != "apple.com" || company.Industry() != "consumer-electronics" {
+		t.Errorf("Unexpected identity: %q %q %q", company.Name(), company.DomainName(), company.Industry())
+	}
+	// Missing sections read as zero values instead of panicking
+	if company.Revenue() != "" || company.ActiveTechnologies() != nil || company.CountryCode() != "" ||
+		company.LinkedInURL() != "" || company.YearFounded() != 0 || company.Description() != "" ||
+		company.EmployeeRange() != "" || company.Industries() != nil {
+		t.Error("Expected zero values for the fields missing from a simplified company")
+	}
+
+	response, err = client.FetchCompany(context.Background(), "apple.com", nil)
+	if err != nil || response.JSON200 == nil {
+		t.Fatalf("FetchCompany returned %v", err)
+	}
+	company = *response.JSON200
+	if company.IsSimplified() {
+		t.Error("Expected a full company not to be detected as simplified")
+	}
+	if company.Revenue() != "over-1b" || len(company.ActiveTechnologies()) != 1 {
+		t.Errorf("Unexpected details: %q %v", company.Revenue(), company.ActiveTechnologies())
+	}
+
+	if (Company{}).IsSimplified() {
+		t.Error("Expected an empty company not to be detected as simplified")
+	}
+}
+
+func TestCompanyHasTechnology(t *testing.T) {
+	company := testCompany(t, `{"technologies":{"active":["Stripe","react"],"categories":[],"details":[{"slug":"google-analytics","detectedAt":"2024-01-01","detectionTypes":[]}]}}`)
+	for _, name := range []string{"stripe", "React", "google-analytics"} {
+		if !company.HasTechnology(name) {
+			t.Errorf("Expected the company to use %s", name)
+		}
+	}
+	if company.HasTechnology("shopify") {
+		t.Error("Expected the company not to use shopify")
+	}
+
+	if (Company{}).HasTechnology("stripe") || (Company{}).ActiveTechnologies() != nil {
+		t.Error("Expected a company without technologies to use none")
+	}
+}
+
+func TestCompanyEmployeeCount(t *testing.T) {
+	tests := []struct {
+		raw      string
+		min, max int
+		ok       bool
+	}{
+		{`{"about":{"totalEmployees":"42"}}`, 42, 42, true},
+		{`{"about":{"totalEmployees":"51-200"}}`, 51, 200, true},
+		{`{"about":{"totalEmployees":"1k-5k"}}`, 1000, 5000, true},
+		{`{"about":{"totalEmployees":"over-10k"}}`, 10000, math.MaxInt, true},
+		{`{"about":{"totalEmployees":"a few"}}`, 0, 0, false},
+		{`{"about":{}}`, 0, 0, false},
+		{`{}`, 0, 0, false},
+	}
+	for _, tt := range tests {
+		min, max, ok := testCompany(t, tt.raw).EmployeeCount()
+		if min != tt.min || max != tt.max || ok != tt.ok {
+			t.Errorf("%s: EmployeeCount() = %d, %d, %v; expected %d, %d, %v", tt.raw, min, max, ok, tt.min, tt.max, tt.ok)
+		}
+	}
+}
+
+func TestCompanySocialProfiles(t *testing.T) {
+	company := testCompany(t, `{"socials":{"linkedin":{"url":"https://linkedin.com/company/apple","followers":100},"twitter":{"url":"https://x.com/apple"},"github":{"url":""}}}`)
+	profiles := company.SocialProfiles()
+	expected := map[string]string{"linkedin": "https://linkedin.com/company/apple", "twitter": "https://x.com/apple"}
+	if !reflect.DeepEqual(profiles, expected) {
+		t.Errorf("Expected %v, got %v", expected, profiles)
+	}
+	if company.LinkedInURL() != "https://linkedin.com/company/apple" || company.LinkedinURL() != company.LinkedInURL() {
+		t.Errorf("Unexpected LinkedIn URL %q", company.LinkedInURL())
+	}
+
+	// Missing social data reads as no profiles
+	company = testCompany(t, `{"domain":{"domain":"apple.com"}}`)
+	if profiles := company.SocialProfiles(); profiles == nil || len(profiles) != 0 {
+		t.Errorf("Expected an empty map, got %v", profiles)
+	}
+	if company.LinkedInURL() != "" {
+		t.Errorf("Expected no LinkedIn URL, got %q", company.LinkedInURL())
+	}
+}
+
+func TestCompanyMerge(t *testing.T) {
+	cached := testCompany(t, `{"about":{"name":"Apple Inc"},"domain":{"domain":"apple.com"}}`)
+	full := testCompany(t, `{"about":{"name":"Apple","industry":"technology"},"domain":{"domain":"apple.com","alias":"apple"},"codes":{"naics":["334220"]}}`)
+
+	merged := cached.Merge(full)
+	// Present fields are kept
+	if merged.Name() != "Apple Inc" {
+		t.Errorf("Expected the cached name to be kept, got %q", merged.Name())
+	}
+	// Missing fields are filled, nested objects included
+	if merged.Industry() != "technology" || merged.Domain.Alias == nil || *merged.Domain.Alias != "apple" {
+		t.Errorf("Expected the missing fields to be filled, got %+v", merged)
+	}
+	if merged.Codes == nil || merged.IsSimplified() {
+		t.Errorf("Expected the detailed sections to be filled, got %+v", merged)
+	}
+	if cached.Codes != nil || cached.About.Industry != nil {
+		t.Error("Expected the cached company to be left untouched")
+	}
+}
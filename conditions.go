@@ -0,0 +1,217 @@
+package thecompaniesapi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrConditionsNotCombinable is returned when a combination of condition groups
+// cannot be expressed as a single flat list of segmentation conditions
+var ErrConditionsNotCombinable = errors.New("conditions cannot be combined into a flat segmentation")
+
+// Segmentation semantics
+//
+// The OpenAPI specification declares the Operator of a condition as an and/or enum
+// without describing how it is evaluated. The combinators below only rely on the two
+// behaviours shown by the search examples of the README: a condition with the Or
+// operator matches any of its Values (e.g. a headquarters country code equal to "us",
+// "gb" or "fr"), and the conditions of a list all apply. The operators of the
+// conditions passed in are never rewritten otherwise.
+
+// CombineAnd merges condition groups so that every group must match, by listing the
+// conditions of all groups in order. Since the conditions of a list all apply, the
+// conditions are kept unchanged. Empty groups are ignored.
+func CombineAnd(groups ...[]SegmentationCondition) ([]SegmentationCondition, error) {
+	var result []SegmentationCondition
+	for _, group := range groups {
+		result = append(result, group...)
+	}
+	return result, nil
+}
+
+// CombineOr merges condition groups so that at least one group must match, as a single
+// condition with the Or operator listing the values of all groups.
+//
+// Every group must hold a single condition, all of them on the same attribute and
+// sign, and each must match any of its values: its operator is Or or it has a single
+// value. Other groups, such as (A AND B) OR C or alternatives on different attributes,
+// cannot be expressed with the behaviours above and result in
+// ErrConditionsNotCombinable. Duplicated values are kept once and empty groups are
+// ignored; a single group is returned unchanged.
+func CombineOr(groups ...[]SegmentationCondition) ([]SegmentationCondition, error) {
+	var alternatives []SegmentationCondition
+	for _, group := range groups {
+		switch len(group) {
+		case 0:
+		case 1:
+			alternatives = append(alternatives, group[0])
+		default:
+			return nil, ErrConditionsNotCombinable
+		}
+	}
+	switch len(alternatives) {
+	case 0:
+		return nil, nil
+	case 1:
+		return alternatives, nil
+	}
+
+	merged := SegmentationCondition{
+		Attribute:       alternatives[0].Attribute,
+		BlockedOperator: alternatives[0].BlockedOperator,
+		Operator:        Or,
+		Sign:            alternatives[0].Sign,
+	}
+	seen := map[string]bool{}
+	for _, condition := range alternatives {
+		if condition.Attribute != merged.Attribute || condition.Sign != merged.Sign ||
+			!sameBlockedOperator(condition.BlockedOperator, merged.BlockedOperator) ||
+			(condition.Operator != Or && len(condition.Values) > 1) {
+			return nil, ErrConditionsNotCombinable
+		}
+		for _, value := range condition.Values {
+			encoded, err := value.MarshalJSON()
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode condition value: %w", err)
+			}
+			if !seen[string(encoded)] {
+				seen[string(encoded)] = true
+				merged.Values = append(merged.Values, value)
+			}
+		}
+	}
+	return []SegmentationCondition{merged}, nil
+}
+
+// sameBlockedOperator reports whether two optional BlockedOperator flags are equal
+func sameBlockedOperator(a, b *bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// ErrInvalidSegmentation is matched by the errors returned by ValidateSegmentation
+var ErrInvalidSegmentation = errors.New("invalid segmentation")
+
+// SegmentationError describes the first invalid condition of a segmentation
+type SegmentationError struct {
+	// Index is the position of the invalid condition
+	Index int
+	// Field is the invalid condition field: attribute, operator, sign or values
+	Field string
+	// Value is the rejected value
+	Value string
+}
+
+func (e *SegmentationError) Error() string {
+	if e.Field == "values" {
+		return fmt.Sprintf("condition %d: at least one value is required", e.Index)
+	}
+	return fmt.Sprintf("condition %d: unknown %s %q", e.Index, e.Field, e.Value)
+}
+
+// Unwrap allows errors.Is(err, ErrInvalidSegmentation)
+func (e *SegmentationError) Unwrap() error {
+	return ErrInvalidSegmentation
+}
+
+// ValidateSegmentation checks the conditions against the attributes, operators and
+// signs known by the OpenAPI specification, for instance before running a search with
+// the conditions produced by PromptToSegmentation. It returns a *SegmentationError
+// for the first invalid condition.
+func ValidateSegmentation(conditions []SegmentationCondition) error {
+	for i, condition := range conditions {
+		for _, field := range []struct{ name, value string }{
+			{"attribute", string(condition.Attribute)},
+			{"operator", string(condition.Operator)},
+			{"sign", string(condition.Sign)},
+		} {
+			allowed, err := schemaPropertyEnum("SegmentationCondition", field.name)
+			if err != nil {
+				return err
+			}
+			if !containsString(allowed, field.value) {
+				return &SegmentationError{Index: i, Field: field.name, Value: field.value}
+			}
+		}
+		if len(condition.Values) == 0 {
+			return &SegmentationError{Index: i, Field: "values"}
+		}
+	}
+	return nil
+}
+
+// ErrUnknownAttribute is returned by ParseAttribute for strings that are not a
+// segmentation attribute of the OpenAPI specification
+var ErrUnknownAttribute = errors.New("unknown segmentation attribute")
+
+// ParseAttribute returns the segmentation attribute of a dotted path such as
+// "about.industries", for instance when reading conditions from a configuration file.
+// Paths are matched exactly first, then case-insensitively.
+func ParseAttribute(s string) (SegmentationConditionAttribute, error) {
+	attributes, err := schemaPropertyEnum("SegmentationCondition", "attribute")
+	if err != nil {
+		return "", err
+	}
+	s = strings.TrimSpace(s)
+	if containsString(attributes, s) {
+		return SegmentationConditionAttribute(s), nil
+	}
+	for _, attribute := range attributes {
+		if strings.EqualFold(attribute, s) {
+			return SegmentationConditionAttribute(attribute), nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownAttribute, s)
+}
+
+// String returns the dotted path of the attribute, e.g. "about.industries"
+func (a SegmentationConditionAttribute) String() string {
+	return string(a)
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ConditionGroup is a boolean group of conditions and nested groups, such as
+// (A OR B) AND (C OR D). The API only accepts flat segmentations: Flatten converts a
+// group to the equivalent []SegmentationCondition when one exists.
+type ConditionGroup struct {
+	// Operator combines the members of the group: And requires all of them, Or any of them
+	Operator   SegmentationConditionOperator
+	Conditions []SegmentationCondition
+	Groups     []ConditionGroup
+}
+
+// Flatten converts the group to a flat segmentation with CombineAnd or CombineOr. The
+// alternatives of an Or group must be on the same attribute and sign, so that they
+// become the values of a single Or condition: (industry A OR industry B) AND
+// (country C OR country D) flattens to two conditions. Groups the API cannot express,
+// like (industry A OR country C), return ErrConditionsNotCombinable.
+func (g ConditionGroup) Flatten() ([]SegmentationCondition, error) {
+	members := make([][]SegmentationCondition, 0, len(g.Conditions)+len(g.Groups))
+	for _, condition := range g.Conditions {
+		members = append(members, []SegmentationCondition{condition})
+	}
+	for _, group := range g.Groups {
+		flattened, err := group.Flatten()
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, flattened)
+	}
+
+	if g.Operator == Or {
+		return CombineOr(members...)
+	}
+	return CombineAnd(members...)
+}
@@ -0,0 +1,273 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func testCondition(attribute SegmentationConditionAttribute, operator SegmentationConditionOperator) SegmentationCondition {
+	return SegmentationCondition{
+		Attribute: attribute,
+		Operator:  operator,
+		Sign:      Equals,
+	}
+}
+
+func assertOperators(t *testing.T, conditions []SegmentationCondition, expected map[SegmentationConditionAttribute]SegmentationConditionOperator) {
+	t.Helper()
+	if len(conditions) != len(expected) {
+		t.Fatalf("Expected %d conditions, got %d", len(expected), len(conditions))
+	}
+	for _, condition := range conditions {
+		if condition.Operator != expected[condition.Attribute] {
+			t.Errorf("Expected operator %s for %s, got %s", expected[condition.Attribute], condition.Attribute, condition.Operator)
+		}
+	}
+}
+
+// testValues returns the values of a condition as strings
+func testValues(t *testing.T, condition SegmentationCondition) []string {
+	t.Helper()
+	values := make([]string, len(condition.Values))
+	for i, value := range condition.Values {
+		v, err := value.AsSegmentationConditionValues0()
+		if err != nil {
+			t.Fatalf("Expected a string value, got %v", err)
+		}
+		values[i] = v
+	}
+	return values
+}
+
+func withValues(t *testing.T, condition SegmentationCondition, values ...string) SegmentationCondition {
+	t.Helper()
+	condition.Values = make([]SegmentationCondition_Values_Item, len(values))
+	for i, value := range values {
+		if err := condition.Values[i].FromSegmentationConditionValues0(value); err != nil {
+			t.Fatalf("Failed to set value: %v", err)
+		}
+	}
+	return condition
+}
+
+func TestCombineAnd(t *testing.T) {
+	industry := testCondition(SegmentationConditionAttributeAboutIndustries, Or)
+	employees := testCondition(SegmentationConditionAttributeAboutTotalEmployees, And)
+	name := testCondition(SegmentationConditionAttributeAboutName, Or)
+
+	// The conditions of every group are listed unchanged
+	result, err := CombineAnd([]SegmentationCondition{industry}, []SegmentationCondition{employees, name}, nil)
+	if err != nil {
+		t.Fatalf("CombineAnd returned error: %v", err)
+	}
+	assertOperators(t, result, map[SegmentationConditionAttribute]SegmentationConditionOperator{
+		industry.Attribute:  Or,
+		employees.Attribute: And,
+		name.Attribute:      Or,
+	})
+	if result[0].Attribute != industry.Attribute || result[2].Attribute != name.Attribute {
+		t.Errorf("Expected the conditions in order, got %v", result)
+	}
+}
+
+func TestCombineOr(t *testing.T) {
+	us := withValues(t, testCondition(SegmentationConditionAttributeLocationsHeadquartersCountryCode, And), "us")
+	europe := withValues(t, testCondition(SegmentationConditionAttributeLocationsHeadquartersCountryCode, Or), "gb", "fr", "us")
+
+	// Alternatives on an attribute become the values of a single Or condition
+	result, err := CombineOr([]SegmentationCondition{us}, nil, []SegmentationCondition{europe})
+	if err != nil {
+		t.Fatalf("CombineOr returned error: %v", err)
+	}
+	assertOperators(t, result, map[SegmentationConditionAttribute]SegmentationConditionOperator{
+		us.Attribute: Or,
+	})
+	if values := testValues(t, result[0]); len(values) != 3 || values[0] != "us" || values[1] != "gb" || values[2] != "fr" {
+		t.Errorf("Expected the values of both groups once, got %v", values)
+	}
+
+	// A single alternative is kept unchanged
+	result, err = CombineOr([]SegmentationCondition{us})
+	if err != nil {
+		t.Fatalf("CombineOr returned error: %v", err)
+	}
+	assertOperators(t, result, map[SegmentationConditionAttribute]SegmentationConditionOperator{
+		us.Attribute: And,
+	})
+
+	name := withValues(t, testCondition(SegmentationConditionAttributeAboutName, And), "acme")
+	allOf := withValues(t, testCondition(SegmentationConditionAttributeLocationsHeadquartersCountryCode, And), "gb", "fr")
+	otherSign := us
+	otherSign.Sign = NotEquals
+	for description, groups := range map[string][][]SegmentationCondition{
+		"(A AND B) OR C":       {{us, name}, {europe}},
+		"different attributes": {{us}, {name}},
+		"different signs":      {{us}, {otherSign}},
+		"several And values":   {{us}, {allOf}},
+	} {
+		if _, err := CombineOr(groups...); !errors.Is(err, ErrConditionsNotCombinable) {
+			t.Errorf("Expected ErrConditionsNotCombinable for %s, got %v", description, err)
+		}
+	}
+}
+
+func TestValidateSegmentation(t *testing.T) {
+	var value SegmentationCondition_Values_Item
+	if err := value.FromSegmentationConditionValues0("software"); err != nil {
+		t.Fatalf("Failed to build value: %v", err)
+	}
+	valid := testCondition(SegmentationConditionAttributeAboutIndustries, And)
+	valid.Values = []SegmentationCondition_Values_Item{value}
+
+	if err := ValidateSegmentation([]SegmentationCondition{valid}); err != nil {
+		t.Fatalf("Expected valid segmentation, got %v", err)
+	}
+
+	// A segmentation generated with a hallucinated attribute
+	invalid := valid
+	invalid.Attribute = "about.revenue"
+	err := ValidateSegmentation([]SegmentationCondition{valid, invalid})
+	if !errors.Is(err, ErrInvalidSegmentation) {
+		t.Fatalf("Expected ErrInvalidSegmentation, got %v", err)
+	}
+	var segmentationErr *SegmentationError
+	if !errors.As(err, &segmentationErr) {
+		t.Fatalf("Expected *SegmentationError, got %T", err)
+	}
+	if segmentationErr.Index != 1 || segmentationErr.Field != "attribute" || segmentationErr.Value != "about.revenue" {
+		t.Errorf("Unexpected error details: %+v", segmentationErr)
+	}
+	if err.Error() != `condition 1: unknown attribute "about.revenue"` {
+		t.Errorf("Unexpected error message: %s", err)
+	}
+
+	invalid = valid
+	invalid.Sign = "contains"
+	if err := ValidateSegmentation([]SegmentationCondition{invalid}); err == nil || err.Error() != `condition 0: unknown sign "contains"` {
+		t.Errorf("Expected unknown sign error, got %v", err)
+	}
+
+	invalid = valid
+	invalid.Values = nil
+	if err := ValidateSegmentation([]SegmentationCondition{invalid}); !errors.As(err, &segmentationErr) || segmentationErr.Field != "values" {
+		t.Errorf("Expected missing values error, got %v", err)
+	}
+}
+
+func TestConditionGroupFlatten(t *testing.T) {
+	ai := withValues(t, testCondition(SegmentationConditionAttributeAboutIndustries, And), "artificial-intelligence")
+	ml := withValues(t, testCondition(SegmentationConditionAttributeAboutIndustries, And), "machine-learning")
+	us := withValues(t, testCondition(SegmentationConditionAttributeLocationsHeadquartersCountryCode, And), "us")
+	gb := withValues(t, testCondition(SegmentationConditionAttributeLocationsHeadquartersCountryCode, And), "gb")
+	name := withValues(t, testCondition(SegmentationConditionAttributeAboutName, And), "acme")
+
+	// (ai OR ml) AND (us OR gb)
+	group := ConditionGroup{
+		Operator: And,
+		Groups: []ConditionGroup{
+			{Operator: Or, Conditions: []SegmentationCondition{ai, ml}},
+			{Operator: Or, Conditions: []SegmentationCondition{us, gb}},
+		},
+	}
+	result, err := group.Flatten()
+	if err != nil {
+		t.Fatalf("Flatten returned error: %v", err)
+	}
+	assertOperators(t, result, map[SegmentationConditionAttribute]SegmentationConditionOperator{
+		ai.Attribute: Or,
+		us.Attribute: Or,
+	})
+	if values := testValues(t, result[0]); len(values) != 2 || values[0] != "artificial-intelligence" || values[1] != "machine-learning" {
+		t.Errorf("Unexpected industries: %v", values)
+	}
+	if values := testValues(t, result[1]); len(values) != 2 || values[0] != "us" || values[1] != "gb" {
+		t.Errorf("Unexpected countries: %v", values)
+	}
+
+	// (ai OR name) AND us has no flat equivalent
+	group = ConditionGroup{
+		Operator:   And,
+		Conditions: []SegmentationCondition{us},
+		Groups:     []ConditionGroup{{Operator: Or, Conditions: []SegmentationCondition{ai, name}}},
+	}
+	if _, err := group.Flatten(); !errors.Is(err, ErrConditionsNotCombinable) {
+		t.Errorf("Expected ErrConditionsNotCombinable, got %v", err)
+	}
+}
+
+func TestSearchCompaniesPostGroup(t *testing.T) {
+	var query []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query []map[string]any `json:"query"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		query = body.Query
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"companies":[],"meta":{}}`))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	// (ai OR ml) AND (us OR gb)
+	industry := testCondition(SegmentationConditionAttributeAboutIndustries, And)
+	country := testCondition(SegmentationConditionAttributeLocationsHeadquartersCountryCode, And)
+	group := ConditionGroup{
+		Operator: And,
+		Groups: []ConditionGroup{
+			{Operator: Or, Conditions: []SegmentationCondition{withValues(t, industry, "artificial-intelligence"), withValues(t, industry, "machine-learning")}},
+			{Operator: Or, Conditions: []SegmentationCondition{withValues(t, country, "us"), withValues(t, country, "gb")}},
+		},
+	}
+	if _, err := client.SearchCompaniesPostGroup(context.Background(), SearchCompaniesPostJSONRequestBody{}, group); err != nil {
+		t.Fatalf("SearchCompaniesPostGroup returned error: %v", err)
+	}
+
+	// The API expects a flat list of conditions carrying their operator
+	expected := `[{"attribute":"about.industries","operator":"or","sign":"equals","values":["artificial-intelligence","machine-learning"]},` +
+		`{"attribute":"locations.headquarters.country.code","operator":"or","sign":"equals","values":["us","gb"]}]`
+	if encoded, _ := json.Marshal(query); string(encoded) != expected {
+		t.Errorf("Expected query %s, got %s", expected, encoded)
+	}
+
+	// Groups without flat equivalent fail before any request
+	query = nil
+	group.Groups[0].Conditions = append(group.Groups[0].Conditions, withValues(t, country, "fr"))
+	if _, err := client.SearchCompaniesPostGroup(context.Background(), SearchCompaniesPostJSONRequestBody{}, group); !errors.Is(err, ErrConditionsNotCombinable) {
+		t.Errorf("Expected ErrConditionsNotCombinable, got %v", err)
+	}
+	if query != nil {
+		t.Errorf("Expected no request, got %v", query)
+	}
+}
+
+func TestParseAttribute(t *testing.T) {
+	for input, expected := range map[string]SegmentationConditionAttribute{
+		"about.industries":     SegmentationConditionAttributeAboutIndustries,
+		" domain.domain ":      SegmentationConditionAttributeDomainDomain,
+		"ABOUT.totalemployees": SegmentationConditionAttributeAboutTotalEmployees,
+		"about.totalEmployees": SegmentationConditionAttributeAboutTotalEmployees,
+	} {
+		attribute, err := ParseAttribute(input)
+		if err != nil || attribute != expected {
+			t.Errorf("ParseAttribute(%q) = %q, %v, expected %q", input, attribute, err, expected)
+		}
+		if attribute.String() != string(expected) {
+			t.Errorf("Expected String to return %q, got %q", expected, attribute.String())
+		}
+	}
+
+	for _, input := range []string{"", "about", "about.revenue", "about.industries.name"} {
+		if _, err := ParseAttribute(input); !errors.Is(err, ErrUnknownAttribute) {
+			t.Errorf("ParseAttribute(%q) returned %v, expected ErrUnknownAttribute", input, err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"net/http"
+)
+
+// WithBaseContext attaches the values of ctx, such as tenant identifiers or trace
+// baggage, to every request of the client. Only the values are used: the deadline and
+// cancellation of ctx do not affect requests. Values of the per-call context take
+// precedence over the base ones.
+//
+// The values are visible to request editors, middlewares, token providers and the
+// transport. Typed operations called on the embedded ClientWithResponses directly
+// (e.g. FetchCompanyWithResponse) only merge them once the request reaches the client
+// pipeline, after their request editors ran.
+func WithBaseContext(ctx context.Context) BaseClientOption {
+	return func(c *BaseClient) {
+		c.baseContext = ctx
+	}
+}
+
+// valuesContext is a context whose values fall back to those of a base context
+type valuesContext struct {
+	context.Context
+	base context.Context
+}
+
+func (c *valuesContext) Value(key any) any {
+	if value := c.Context.Value(key); value != nil {
+		return value
+	}
+	return c.base.Value(key)
+}
+
+// requestContext merges the values of the base context into the context of a call
+func (c *BaseClient) requestContext(ctx context.Context) context.Context {
+	if c.baseContext == nil {
+		return ctx
+	}
+	if merged, ok := ctx.(*valuesContext); ok && merged.base == c.baseContext {
+		return ctx
+	}
+	return &valuesContext{Context: ctx, base: c.baseContext}
+}
+
+// withRequestContext returns the request with the base context values merged
+func (c *BaseClient) withRequestContext(req *http.Request) *http.Request {
+	if c.baseContext == nil {
+		return req
+	}
+	return req.WithContext(c.requestContext(req.Context()))
+}
+
+// callContext merges the values of the base context into the context of a typed
+// operation, so that its request editors see them
+func (c *CompaniesAPIClient) callContext(ctx context.Context) context.Context {
+	return c.baseClient.requestContext(ctx)
+}
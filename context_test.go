@@ -0,0 +1,64 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type tenantKey struct{}
+
+// contextRecorder records the tenant of the requests it forwards
+type contextRecorder struct {
+	tenant any
+}
+
+func (r *contextRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.tenant = req.Context().Value(tenantKey{})
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestWithBaseContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	// The cancellation of the base context does not apply to requests
+	base, cancel := context.WithCancel(context.WithValue(context.Background(), tenantKey{}, "acme"))
+	cancel()
+
+	recorder := &contextRecorder{}
+	httpClient := &http.Client{Transport: recorder}
+
+	client, err := ApiClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithCustomHTTPClient(httpClient),
+		WithBaseContext(base),
+	)
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	var editorTenant any
+	editor := func(ctx context.Context, req *http.Request) error {
+		editorTenant = ctx.Value(tenantKey{})
+		return nil
+	}
+	if _, err := client.FetchCompany(context.Background(), "apple.com", nil, editor); err != nil {
+		t.Fatalf("FetchCompany returned error: %v", err)
+	}
+	if editorTenant != "acme" || recorder.tenant != "acme" {
+		t.Errorf("Expected the base context value in the editor and transport, got %v and %v", editorTenant, recorder.tenant)
+	}
+
+	// Values of the call context take precedence
+	if _, err := client.FetchCompany(context.WithValue(context.Background(), tenantKey{}, "globex"), "apple.com", nil, editor); err != nil {
+		t.Fatalf("FetchCompany returned error: %v", err)
+	}
+	if editorTenant != "globex" {
+		t.Errorf("Expected the call context value, got %v", editorTenant)
+	}
+}
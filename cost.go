@@ -0,0 +1,52 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"net/http"
+)
+
+// CreditsPerCompany is the number of credits per company returned by a search that is
+// assumed by the EstimateSearchCost heuristic
+const CreditsPerCompany = 1
+
+// SearchCostEstimate is the credit cost of a search estimated by EstimateSearchCost
+type SearchCostEstimate struct {
+	// Credits is the estimated number of credits consumed by the search
+	Credits float64
+	// Matches is the number of companies matching the search
+	Matches int64
+	// CostPerCompany is the cost of a company the estimate is based on
+	CostPerCompany float64
+}
+
+// EstimateSearchCost estimates the credits a search would consume before running it.
+// The API offers no cost preview: the companies matching the query and search of body
+// are counted with CountCompaniesPost, and the cost is computed client-side as the
+// cost of a company for every company of the requested page, min(matches, size).
+// The cost of a company is CreditsPerCompany. When body.Size is not set, size defaults
+// to MaxSearchSize (100) so that the estimate is an upper bound: this is not the
+// default page size of the API, which applies its own when size is omitted, so set
+// body.Size for an estimate of the page actually returned.
+func (c *CompaniesAPIClient) EstimateSearchCost(ctx context.Context, body SearchCompaniesPostJSONRequestBody) (*SearchCostEstimate, error) {
+	response, err := c.CountCompaniesPost(ctx, body.ToCountBody())
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() != http.StatusOK {
+		return nil, c.baseClient.responseError(response.HTTPResponse, response.Body)
+	}
+	matches, err := parseExactCount(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	size := int64(MaxSearchSize)
+	if body.Size != nil {
+		size = int64(*body.Size)
+	}
+	return &SearchCostEstimate{
+		Credits:        float64(min(matches, size)) * CreditsPerCompany,
+		Matches:        matches,
+		CostPerCompany: CreditsPerCompany,
+	}, nil
+}
@@ -0,0 +1,60 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEstimateSearchCost(t *testing.T) {
+	var counted map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v2/companies/apple.com" {
+			w.Write([]byte(`{"domain":{"domain":"apple.com"},"meta":{"cost":0.5,"credits":99.5}}`))
+			return
+		}
+		if r.URL.Path != "/v2/companies/count" {
+			t.Errorf("Expected a count request, got %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&counted)
+		w.Write([]byte(`{"count":250}`))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+	ctx := context.Background()
+	search, size := "saas", float32(20)
+	body := SearchCompaniesPostJSONRequestBody{Search: &search, Size: &size}
+
+	estimate, err := client.EstimateSearchCost(ctx, body)
+	if err != nil {
+		t.Fatalf("EstimateSearchCost returned error: %v", err)
+	}
+	if *estimate != (SearchCostEstimate{Credits: 20, Matches: 250, CostPerCompany: CreditsPerCompany}) {
+		t.Errorf("Unexpected estimate: %+v", *estimate)
+	}
+	if counted["search"] != "saas" || counted["size"] != nil {
+		t.Errorf("Expected the search to be counted, got %v", counted)
+	}
+	if estimate, _ := client.EstimateSearchCost(ctx, SearchCompaniesPostJSONRequestBody{}); estimate.Credits != MaxSearchSize {
+		t.Errorf("Expected a page of MaxSearchSize companies without size, got %v", estimate.Credits)
+	}
+
+	// The estimates do not depend on the responses previously received by the client
+	if _, err := client.FetchCompany(ctx, "apple.com", nil); err != nil {
+		t.Fatalf("FetchCompany returned error: %v", err)
+	}
+	estimate, err = client.EstimateSearchCost(ctx, body)
+	if err != nil {
+		t.Fatalf("EstimateSearchCost returned error: %v", err)
+	}
+	if *estimate != (SearchCostEstimate{Credits: 20, Matches: 250, CostPerCompany: CreditsPerCompany}) {
+		t.Errorf("Unexpected estimate: %+v", *estimate)
+	}
+}
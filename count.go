@@ -0,0 +1,107 @@
+package thecompaniesapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// CountCompaniesExact counts the companies matching the params like CountCompanies,
+// but parses the count from the raw response body so that counts above 2^24 keep
+// their exact value instead of being rounded by the generated float32 field.
+func (c *CompaniesAPIClient) CountCompaniesExact(ctx context.Context, params *CountCompaniesParams) (int64, error) {
+	response, err := c.CountCompanies(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+	if response.StatusCode() != http.StatusOK {
+		return 0, c.baseClient.responseError(response.HTTPResponse, response.Body)
+	}
+	return parseExactCount(response.Body)
+}
+
+// CountCompaniesPostExact is the POST equivalent of CountCompaniesExact
+func (c *CompaniesAPIClient) CountCompaniesPostExact(ctx context.Context, body CountCompaniesPostJSONRequestBody) (int64, error) {
+	response, err := c.CountCompaniesPost(ctx, body)
+	if err != nil {
+		return 0, err
+	}
+	if response.StatusCode() != http.StatusOK {
+		return 0, c.baseClient.responseError(response.HTTPResponse, response.Body)
+	}
+	return parseExactCount(response.Body)
+}
+
+// CountCompaniesBatch counts the companies matching every query with
+// CountCompaniesPostExact, running at most concurrency counts at a time (at least
+// one), and returns the counts by label. A failed count does not stop the others: the
+// counts hold the successful queries and the error, when not nil, joins the errors of
+// the failed ones, each prefixed with its label.
+func (c *CompaniesAPIClient) CountCompaniesBatch(ctx context.Context, queries map[string][]SegmentationCondition, concurrency int) (map[string]int64, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	labels := make([]string, 0, len(queries))
+	for label := range queries {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		counts = make(map[string]int64, len(queries))
+		errs   = make([]error, len(labels))
+		slots  = make(chan struct{}, concurrency)
+	)
+	for i, label := range labels {
+		query := queries[label]
+		wg.Add(1)
+		go func(i int, label string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			count, err := c.CountCompaniesPostExact(ctx, CountCompaniesPostJSONRequestBody{Query: &query})
+			if err != nil {
+				errs[i] = fmt.Errorf("count %q: %w", label, err)
+				return
+			}
+			mu.Lock()
+			counts[label] = count
+			mu.Unlock()
+		}(i, label)
+	}
+	wg.Wait()
+
+	return counts, errors.Join(errs...)
+}
+
+// parseExactCount reads the "count" field of a count response without float32 rounding
+func parseExactCount(body []byte) (int64, error) {
+	var payload struct {
+		Count json.Number `json:"count"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&payload); err != nil {
+		return 0, fmt.Errorf("failed to decode count response: %w", err)
+	}
+
+	if count, err := payload.Count.Int64(); err == nil {
+		return count, nil
+	}
+	// Counts serialized with a fractional or exponent notation
+	count, err := strconv.ParseFloat(payload.Count.String(), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid count %q: %w", payload.Count, err)
+	}
+	return int64(math.Round(count)), nil
+}
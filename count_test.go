@@ -0,0 +1,131 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCountCompaniesExact(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"count":16777217}`))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	// The generated float32 field rounds the value
+	response, err := client.CountCompanies(context.Background(), &CountCompaniesParams{})
+	if err != nil {
+		t.Fatalf("CountCompanies failed: %v", err)
+	}
+	if int64(response.JSON200.Count) == 16777217 {
+		t.Fatal("Expected the float32 count to lose precision")
+	}
+
+	count, err := client.CountCompaniesExact(context.Background(), &CountCompaniesParams{})
+	if err != nil {
+		t.Fatalf("CountCompaniesExact failed: %v", err)
+	}
+	if count != 16777217 {
+		t.Errorf("Expected exact count 16777217, got %d", count)
+	}
+
+	count, err = client.CountCompaniesPostExact(context.Background(), CountCompaniesPostJSONRequestBody{})
+	if err != nil {
+		t.Fatalf("CountCompaniesPostExact failed: %v", err)
+	}
+	if count != 16777217 {
+		t.Errorf("Expected exact count 16777217, got %d", count)
+	}
+}
+
+func TestParseExactCount(t *testing.T) {
+	tests := map[string]int64{
+		`{"count":0}`:                0,
+		`{"count":9007199254740993}`: 9007199254740993,
+		`{"count":1.5e3}`:            1500,
+	}
+	for body, expected := range tests {
+		count, err := parseExactCount([]byte(body))
+		if err != nil {
+			t.Errorf("parseExactCount(%s) returned error: %v", body, err)
+		} else if count != expected {
+			t.Errorf("parseExactCount(%s) = %d, expected %d", body, count, expected)
+		}
+	}
+
+	if _, err := parseExactCount([]byte(`{"count":"many"}`)); err == nil {
+		t.Error("Expected an error for a non numeric count")
+	}
+}
+
+func TestCountCompaniesBatch(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			observed := maxInFlight.Load()
+			if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		var body struct {
+			Query []struct {
+				Values []string `json:"values"`
+			} `json:"query"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		value := body.Query[0].Values[0]
+		if value == "unknown" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":"invalid_query","message":"Unknown industry"}`))
+			return
+		}
+		fmt.Fprintf(w, `{"count":%d}`, len(value))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	queries := map[string][]SegmentationCondition{}
+	for _, industry := range []string{"saas", "fintech", "biotech", "retail", "gaming", "unknown"} {
+		condition := testCondition(SegmentationConditionAttributeAboutIndustries, And)
+		var value SegmentationCondition_Values_Item
+		value.FromSegmentationConditionValues0(industry)
+		condition.Values = []SegmentationCondition_Values_Item{value}
+		queries["industry "+industry] = []SegmentationCondition{condition}
+	}
+
+	counts, err := client.CountCompaniesBatch(context.Background(), queries, 2)
+	expected := map[string]int64{"industry saas": 4, "industry fintech": 7, "industry biotech": 7, "industry retail": 6, "industry gaming": 6}
+	if !reflect.DeepEqual(counts, expected) {
+		t.Errorf("Expected counts %v, got %v", expected, counts)
+	}
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Code != "invalid_query" || !strings.HasPrefix(err.Error(), `count "industry unknown": `) {
+		t.Errorf("Expected the failed query to be reported, got %v", err)
+	}
+	if peak := maxInFlight.Load(); peak > 2 {
+		t.Errorf("Expected at most 2 concurrent counts, got %d", peak)
+	}
+}
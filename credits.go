@@ -0,0 +1,159 @@
+package thecompaniesapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"sync"
+)
+
+// CreditsEvent reports the credits consumed by a request attempt
+type CreditsEvent struct {
+	// Operation is the API operation of the request, e.g. "FetchCompany"
+	Operation string
+	// Credits is the number of credits consumed, from the meta.cost field of the response
+	Credits float64
+	// Remaining is the number of credits left to the team after the call, from the
+	// meta.credits field, nil when the response does not report it
+	Remaining *float64
+}
+
+// responseCredits is the meta object in which company and search responses report
+// the cost of the call and the remaining credits of the team
+type responseCredits struct {
+	Meta *struct {
+		Cost    *float64 `json:"cost"`
+		Credits *float64 `json:"credits"`
+	} `json:"meta"`
+}
+
+// ParseCreditsUsed extracts the credits consumed by a call from the meta.cost field of
+// its JSON response body, reporting false when the body does not carry it
+func ParseCreditsUsed(body []byte) (float64, bool) {
+	credits, _, ok := parseResponseCredits(body)
+	return credits, ok
+}
+
+// parseResponseCredits reads the meta.cost and meta.credits fields of a response body
+func parseResponseCredits(body []byte) (credits float64, remaining *float64, ok bool) {
+	var response responseCredits
+	if err := json.Unmarshal(body, &response); err != nil || response.Meta == nil {
+		return 0, nil, false
+	}
+	return creditsMeta(response.Meta.Cost, response.Meta.Credits)
+}
+
+// parseMetaCredits reads the cost and credits fields of a response meta object
+func parseMetaCredits(meta []byte) (credits float64, remaining *float64, ok bool) {
+	return parseResponseCredits([]byte(`{"meta":` + string(meta) + `}`))
+}
+
+// creditsMeta validates the cost and remaining credits of a response meta
+func creditsMeta(cost, credits *float64) (float64, *float64, bool) {
+	if cost == nil || !validCredits(*cost) {
+		return 0, nil, false
+	}
+	if credits != nil && !validCredits(*credits) {
+		credits = nil
+	}
+	return *cost, credits, true
+}
+
+// validCredits reports whether a number of credits is finite and not negative
+func validCredits(credits float64) bool {
+	return credits >= 0 && !math.IsInf(credits, 0) && !math.IsNaN(credits)
+}
+
+// creditsRecorder keeps the credits consumed by the last response received by a client
+type creditsRecorder struct {
+	mu      sync.Mutex
+	credits float64
+	ok      bool
+}
+
+func (r *creditsRecorder) record(credits float64, ok bool) {
+	r.mu.Lock()
+	r.credits, r.ok = credits, ok
+	r.mu.Unlock()
+}
+
+func (r *creditsRecorder) last() (float64, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.credits, r.ok
+}
+
+// streamedResponseKey marks the requests whose response body is decoded incrementally:
+// send does not buffer it to read its credits, the caller reporting them instead
+type streamedResponseKey struct{}
+
+// withStreamedResponse marks the requests of ctx as having a streamed response
+func withStreamedResponse(ctx context.Context) context.Context {
+	return context.WithValue(ctx, streamedResponseKey{}, true)
+}
+
+// WithCreditsTracking makes the client record the credits consumed by every response,
+// as returned by LastCreditsUsed. To read their meta object, successful JSON responses
+// are then buffered once more before being decoded. Tracking is also enabled by the
+// CreditsUsed hook of WithObserver; without either, the credits of a call are read
+// from the Meta field of its decoded response, e.g. response.JSON200.Meta.Cost.
+func WithCreditsTracking() BaseClientOption {
+	return func(c *BaseClient) {
+		c.trackCredits = true
+	}
+}
+
+// tracksCredits reports whether the client records the credits of its responses
+func (c *BaseClient) tracksCredits() bool {
+	return c.trackCredits || (c.observer != nil && c.observer.CreditsUsed != nil)
+}
+
+// recordCredits records the credits consumed by a response and reports them to the
+// CreditsUsed hook of the observer. When credits are tracked, successful JSON bodies
+// are buffered to read their meta object, except for streamed responses.
+func (c *BaseClient) recordCredits(req *http.Request, resp *http.Response) error {
+	if !c.tracksCredits() || req.Context().Value(streamedResponseKey{}) != nil {
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 || mediaType != "application/json" {
+		c.credits.record(0, false)
+		return nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	credits, remaining, ok := parseResponseCredits(body)
+	c.reportCredits(req, credits, remaining, ok)
+	return nil
+}
+
+// reportCredits records the credits consumed by a call
+func (c *BaseClient) reportCredits(req *http.Request, credits float64, remaining *float64, ok bool) {
+	c.credits.record(credits, ok)
+	if ok && c.observer != nil && c.observer.CreditsUsed != nil {
+		c.observer.CreditsUsed(CreditsEvent{Operation: requestOperationName(req), Credits: credits, Remaining: remaining})
+	}
+}
+
+// LastCreditsUsed returns the credits consumed by the last response received by the
+// client, as reported in its meta.cost field. It reports false when the response did
+// not carry one, or when credits are not tracked: see WithCreditsTracking. When the client is shared by several goroutines the last response
+// may belong to another goroutine: use the CreditsUsed hook of WithObserver or
+// ParseCreditsUsed on the response body to track the credits of specific calls.
+func (c *BaseClient) LastCreditsUsed() (float64, bool) {
+	return c.credits.last()
+}
+
+// LastCreditsUsed returns the credits consumed by the last response received by the client
+func (c *CompaniesAPIClient) LastCreditsUsed() (float64, bool) {
+	return c.baseClient.LastCreditsUsed()
+}
@@ -0,0 +1,136 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestCreditsUsed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v2/companies/apple.com":
+			w.Write([]byte(`{"domain":{"domain":"apple.com"},"meta":{"cost":2.5,"credits":97.5}}`))
+		case "/v2/companies":
+			w.Write([]byte(`{"companies":[{"domain":{"domain":"apple.com"}}],"meta":{"cost":1,"credits":96.5,"perPage":1}}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	var (
+		mu     sync.Mutex
+		events []CreditsEvent
+	)
+	client, err := ApiClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithObserver(Observer{CreditsUsed: func(event CreditsEvent) {
+			mu.Lock()
+			events = append(events, event)
+			mu.Unlock()
+		}}),
+	)
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+	ctx := context.Background()
+
+	if _, ok := client.LastCreditsUsed(); ok {
+		t.Error("Expected no credits before any call")
+	}
+	response, err := client.FetchCompany(ctx, "apple.com", nil)
+	if err != nil {
+		t.Fatalf("FetchCompany returned error: %v", err)
+	}
+	if credits, ok := client.LastCreditsUsed(); !ok || credits != 2.5 {
+		t.Errorf("Expected 2.5 credits, got %v (%v)", credits, ok)
+	}
+	if credits, ok := ParseCreditsUsed(response.Body); !ok || credits != 2.5 {
+		t.Errorf("Expected the response to carry 2.5 credits, got %v (%v)", credits, ok)
+	}
+	if response.JSON200 == nil || response.JSON200.DomainName() != "apple.com" {
+		t.Errorf("Expected the buffered body to be decoded, got %s", response.Body)
+	}
+
+	// Responses without a meta cost are not reported
+	if _, err := client.FetchApiHealth(ctx); err != nil {
+		t.Fatalf("FetchApiHealth returned error: %v", err)
+	}
+	if _, ok := client.LastCreditsUsed(); ok {
+		t.Error("Expected no credits for the last call")
+	}
+	if len(events) != 1 || events[0].Operation != "FetchCompany" || events[0].Credits != 2.5 ||
+		events[0].Remaining == nil || *events[0].Remaining != 97.5 {
+		t.Errorf("Unexpected credits events: %+v", events)
+	}
+
+	// Streamed responses report their credits once decoded
+	if _, err := client.StreamSearchCompaniesPost(ctx, SearchCompaniesPostJSONRequestBody{}, func(Company) error { return nil }); err != nil {
+		t.Fatalf("StreamSearchCompaniesPost returned error: %v", err)
+	}
+	if credits, ok := client.LastCreditsUsed(); !ok || credits != 1 {
+		t.Errorf("Expected 1 credit for the streamed search, got %v (%v)", credits, ok)
+	}
+	if len(events) != 2 || events[1].Operation != "SearchCompaniesPost" || *events[1].Remaining != 96.5 {
+		t.Errorf("Unexpected credits events: %+v", events)
+	}
+
+	for _, body := range []string{"", "{}", `{"meta":{}}`, `{"meta":{"cost":"free"}}`, `{"meta":{"cost":-1}}`} {
+		if _, ok := ParseCreditsUsed([]byte(body)); ok {
+			t.Errorf("Expected %q to be rejected", body)
+		}
+	}
+}
+
+func TestWithCreditsTracking(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v2/companies/apple.com":
+			w.Write([]byte(`{"domain":{"domain":"apple.com"},"meta":{"cost":2.5,"credits":97.5}}`))
+		case "/v2/companies":
+			w.Write([]byte(`{"companies":[{"domain":{"domain":"apple.com"}}],"meta":{"perPage":1}}`))
+		}
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	// Credits are not tracked by default
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+	response, err := client.FetchCompany(ctx, "apple.com", nil)
+	if err != nil {
+		t.Fatalf("FetchCompany returned error: %v", err)
+	}
+	if _, ok := client.LastCreditsUsed(); ok {
+		t.Error("Expected no credits without tracking")
+	}
+	if response.JSON200 == nil || response.JSON200.Meta == nil || response.JSON200.Meta.Cost == nil || *response.JSON200.Meta.Cost != 2.5 {
+		t.Errorf("Expected the decoded response to carry its meta, got %s", response.Body)
+	}
+
+	client, err = ApiClient("test-api-key", WithCustomBaseURL(server.URL), WithCreditsTracking())
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+	if _, err := client.FetchCompany(ctx, "apple.com", nil); err != nil {
+		t.Fatalf("FetchCompany returned error: %v", err)
+	}
+	if credits, ok := client.LastCreditsUsed(); !ok || credits != 2.5 {
+		t.Errorf("Expected 2.5 credits, got %v (%v)", credits, ok)
+	}
+
+	// A streamed meta without cost reports no credits
+	if _, err := client.StreamSearchCompaniesPost(ctx, SearchCompaniesPostJSONRequestBody{}, func(Company) error { return nil }); err != nil {
+		t.Fatalf("StreamSearchCompaniesPost returned error: %v", err)
+	}
+	if credits, ok := client.LastCreditsUsed(); ok {
+		t.Errorf("Expected no credits for the streamed search, got %v", credits)
+	}
+}
@@ -0,0 +1,133 @@
+package thecompaniesapi
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// WithRequestDeduplication makes concurrent identical GET requests share a single API
+// call, saving credits when many goroutines look up the same company at once. Requests
+// are identical when their URL and headers match; each caller receives its own copy of
+// the shared response. The shared call is not bound to the cancellation or deadline of
+// any caller: each caller stops waiting once its own context is done, and the call is
+// cancelled when no caller waits for it anymore.
+func WithRequestDeduplication() BaseClientOption {
+	return func(c *BaseClient) {
+		c.dedup = &requestDeduplicator{calls: map[string]*sharedCall{}}
+	}
+}
+
+// requestDeduplicator shares the in-flight calls of identical requests
+type requestDeduplicator struct {
+	group singleflight.Group
+
+	mu    sync.Mutex
+	calls map[string]*sharedCall
+}
+
+// sharedCall is the context of an in-flight shared call and the number of callers
+// waiting for it
+type sharedCall struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	waiters int
+}
+
+// join registers a caller of the shared call of key, creating its context from the
+// values of ctx when there is no call in flight
+func (d *requestDeduplicator) join(ctx context.Context, key string) *sharedCall {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	call, ok := d.calls[key]
+	if !ok {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &sharedCall{ctx: callCtx, cancel: cancel}
+		d.calls[key] = call
+	}
+	call.waiters++
+	return call
+}
+
+// leave unregisters a caller of the shared call of key, cancelling the call once
+// nobody waits for it so that the next identical request starts a new one
+func (d *requestDeduplicator) leave(key string, call *sharedCall) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	call.waiters--
+	if call.waiters == 0 {
+		call.cancel()
+		delete(d.calls, key)
+		d.group.Forget(key)
+	}
+}
+
+// sharedResponse is a response buffered to be handed to several callers
+type sharedResponse struct {
+	resp *http.Response
+	body []byte
+}
+
+// doShared sends a GET request once for all the concurrent identical callers
+func (c *BaseClient) doShared(req *http.Request, send roundTripFunc) (*http.Response, error) {
+	key := deduplicationKey(req)
+	call := c.dedup.join(req.Context(), key)
+	defer c.dedup.leave(key, call)
+
+	results := c.dedup.group.DoChan(key, func() (interface{}, error) {
+		resp, err := send(req.WithContext(call.ctx))
+		if err != nil {
+			return nil, err
+		}
+		return bufferResponse(resp)
+	})
+	select {
+	case result := <-results:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return result.Val.(*sharedResponse).copy(), nil
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+}
+
+// bufferResponse reads and closes the body of resp to share it
+func bufferResponse(resp *http.Response) (*sharedResponse, error) {
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &sharedResponse{resp: resp, body: body}, nil
+}
+
+// copy returns a copy of the shared response for one of its callers
+func (s *sharedResponse) copy() *http.Response {
+	resp := *s.resp
+	resp.Header = s.resp.Header.Clone()
+	resp.Body = io.NopCloser(bytes.NewReader(s.body))
+	return &resp
+}
+
+// deduplicationKey identifies identical requests by method, URL and headers
+func deduplicationKey(req *http.Request) string {
+	var key strings.Builder
+	key.WriteString(req.Method + " " + req.URL.String())
+
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		key.WriteString("\n" + name + ": " + strings.Join(req.Header[name], ", "))
+	}
+	return key.String()
+}
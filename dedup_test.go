@@ -0,0 +1,130 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithRequestDeduplication(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		time.Sleep(200 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"about":{"name":"Apple"}}`))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL), WithRequestDeduplication())
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response, err := client.FetchCompany(context.Background(), "apple.com", nil)
+			if err != nil {
+				t.Errorf("FetchCompany returned error: %v", err)
+				return
+			}
+			if response.JSON200 == nil || response.JSON200.Name() != "Apple" {
+				t.Errorf("Expected every caller to decode the shared response, got %s", response.Body)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := hits.Load(); n != 1 {
+		t.Errorf("Expected a single HTTP request, got %d", n)
+	}
+
+	// Different requests are not shared
+	hits.Store(0)
+	wg.Add(2)
+	for _, domain := range []string{"apple.com", "stripe.com"} {
+		go func(domain string) {
+			defer wg.Done()
+			client.FetchCompany(context.Background(), domain, nil)
+		}(domain)
+	}
+	wg.Wait()
+	if n := hits.Load(); n != 2 {
+		t.Errorf("Expected 2 HTTP requests for different domains, got %d", n)
+	}
+}
+
+func TestRequestDeduplicationCancellation(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	cancelled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+			close(cancelled)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"about":{"name":"Apple"}}`))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL), WithRequestDeduplication())
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	// The caller starting the shared call gives up without failing the other callers
+	first, cancelFirst := context.WithCancel(context.Background())
+	firstDone := make(chan error, 1)
+	go func() {
+		_, err := client.FetchCompany(first, "apple.com", nil)
+		firstDone <- err
+	}()
+	for hits.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	secondDone := make(chan error, 1)
+	go func() {
+		response, err := client.FetchCompany(context.Background(), "apple.com", nil)
+		if err == nil && (response.JSON200 == nil || response.JSON200.Name() != "Apple") {
+			t.Errorf("Unexpected response: %s", response.Body)
+		}
+		secondDone <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancelFirst()
+	if err := <-firstDone; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the first caller to be cancelled, got %v", err)
+	}
+	close(release)
+	if err := <-secondDone; err != nil {
+		t.Errorf("Expected the second caller to receive the shared response, got %v", err)
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("Expected a single HTTP request, got %d", n)
+	}
+
+	// The shared call is cancelled once no caller waits for it
+	release = make(chan struct{})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.FetchCompany(ctx, "stripe.com", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the deadline of the caller, got %v", err)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("Expected the abandoned shared call to be cancelled")
+	}
+}
@@ -0,0 +1,105 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrNoDefaultClient is returned by the package-level functions when no default client
+// has been configured with Configure or SetDefaultClient
+var ErrNoDefaultClient = errors.New("no default client configured")
+
+var (
+	defaultClientMu sync.RWMutex
+	defaultClient   *CompaniesAPIClient
+)
+
+// SetDefaultClient sets the client used by the package-level functions such as
+// SearchCompanies and FetchCompany, meant for simple scripts which do not want to pass
+// a client around. A nil client unsets it. It is safe for concurrent use.
+func SetDefaultClient(client *CompaniesAPIClient) {
+	defaultClientMu.Lock()
+	defer defaultClientMu.Unlock()
+	defaultClient = client
+}
+
+// Configure creates a client with ApiClient and sets it as the default client
+func Configure(apiKey string, options ...BaseClientOption) error {
+	client, err := ApiClient(apiKey, options...)
+	if err != nil {
+		return err
+	}
+	SetDefaultClient(client)
+	return nil
+}
+
+// DefaultClient returns the default client, nil when none is configured
+func DefaultClient() *CompaniesAPIClient {
+	defaultClientMu.RLock()
+	defer defaultClientMu.RUnlock()
+	return defaultClient
+}
+
+// requireDefaultClient returns the default client or ErrNoDefaultClient
+func requireDefaultClient() (*CompaniesAPIClient, error) {
+	client := DefaultClient()
+	if client == nil {
+		return nil, ErrNoDefaultClient
+	}
+	return client, nil
+}
+
+// SearchCompanies searches companies with the default client
+func SearchCompanies(ctx context.Context, params *SearchCompaniesParams, reqEditors ...RequestEditorFn) (*SearchCompaniesResponse, error) {
+	client, err := requireDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.SearchCompanies(ctx, params, reqEditors...)
+}
+
+// SearchCompaniesPost searches companies with a request body using the default client
+func SearchCompaniesPost(ctx context.Context, body SearchCompaniesPostJSONRequestBody, reqEditors ...RequestEditorFn) (*SearchCompaniesPostResponse, error) {
+	client, err := requireDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.SearchCompaniesPost(ctx, body, reqEditors...)
+}
+
+// CountCompanies counts companies with the default client
+func CountCompanies(ctx context.Context, params *CountCompaniesParams, reqEditors ...RequestEditorFn) (*CountCompaniesResponse, error) {
+	client, err := requireDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.CountCompanies(ctx, params, reqEditors...)
+}
+
+// FetchCompany fetches a company by domain with the default client
+func FetchCompany(ctx context.Context, domain string, params *FetchCompanyParams, reqEditors ...RequestEditorFn) (*FetchCompanyResponse, error) {
+	client, err := requireDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.FetchCompany(ctx, domain, params, reqEditors...)
+}
+
+// FetchCompanyByEmail fetches a company by email with the default client
+func FetchCompanyByEmail(ctx context.Context, params *FetchCompanyByEmailParams, reqEditors ...RequestEditorFn) (*FetchCompanyByEmailResponse, error) {
+	client, err := requireDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.FetchCompanyByEmail(ctx, params, reqEditors...)
+}
+
+// FetchCompanyBySocial fetches a company by social profile with the default client
+func FetchCompanyBySocial(ctx context.Context, params *FetchCompanyBySocialParams, reqEditors ...RequestEditorFn) (*FetchCompanyBySocialResponse, error) {
+	client, err := requireDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.FetchCompanyBySocial(ctx, params, reqEditors...)
+}
@@ -0,0 +1,61 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestDefaultClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Basic default-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v2/companies":
+			w.Write([]byte(`{"companies":[{"domain":{"domain":"apple.com"}}],"meta":{"total":1}}`))
+		default:
+			w.Write([]byte(`{"domain":{"domain":"apple.com"}}`))
+		}
+	}))
+	defer server.Close()
+	t.Cleanup(func() { SetDefaultClient(nil) })
+
+	ctx := context.Background()
+	SetDefaultClient(nil)
+	if _, err := SearchCompanies(ctx, nil); err != ErrNoDefaultClient {
+		t.Fatalf("Expected ErrNoDefaultClient, got %v", err)
+	}
+
+	if err := Configure("default-key", WithCustomBaseURL(server.URL)); err != nil {
+		t.Fatalf("Configure returned error: %v", err)
+	}
+	response, err := SearchCompanies(ctx, &SearchCompaniesParams{})
+	if err != nil {
+		t.Fatalf("SearchCompanies returned error: %v", err)
+	}
+	if response.JSON200 == nil || len(response.JSON200.Companies) != 1 {
+		t.Fatalf("Unexpected search response: %s", response.Body)
+	}
+
+	company, err := FetchCompany(ctx, "apple.com", nil)
+	if err != nil || company.JSON200 == nil || company.JSON200.DomainName() != "apple.com" {
+		t.Fatalf("Unexpected FetchCompany result: %v", err)
+	}
+
+	// The default client can be swapped while in use
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			SetDefaultClient(DefaultClient())
+			FetchCompany(ctx, "apple.com", nil)
+		}()
+	}
+	wg.Wait()
+}
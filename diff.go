@@ -0,0 +1,66 @@
+package thecompaniesapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// FieldChange is a field whose value differs between two company snapshots. Path is
+// the dotted JSON path of the field (e.g. "about.totalEmployees") and Old and New hold
+// the JSON decoded values, nil when the field is missing from a snapshot.
+type FieldChange struct {
+	Path string
+	Old  any
+	New  any
+}
+
+// DiffCompanies returns the fields that changed between two snapshots of a company,
+// sorted by path. Nested objects are compared field by field while lists are
+// compared as a whole. Fields that become nil or are set for the first time are
+// reported with a nil Old or New value.
+func DiffCompanies(previous, current Company) []FieldChange {
+	var changes []FieldChange
+	diffValues("", companyValues(previous), companyValues(current), &changes)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// companyValues decodes a company into its JSON representation
+func companyValues(company Company) map[string]any {
+	var values map[string]any
+	encoded, err := json.Marshal(company)
+	if err != nil {
+		return nil
+	}
+	json.Unmarshal(encoded, &values)
+	return values
+}
+
+// diffValues appends the changes between two JSON values to changes
+func diffValues(path string, previous, current any, changes *[]FieldChange) {
+	oldObject, oldIsObject := previous.(map[string]any)
+	newObject, newIsObject := current.(map[string]any)
+	if !oldIsObject || !newIsObject {
+		if !reflect.DeepEqual(previous, current) {
+			*changes = append(*changes, FieldChange{Path: path, Old: previous, New: current})
+		}
+		return
+	}
+
+	for key, oldValue := range oldObject {
+		diffValues(joinPath(path, key), oldValue, newObject[key], changes)
+	}
+	for key, newValue := range newObject {
+		if _, ok := oldObject[key]; !ok {
+			diffValues(joinPath(path, key), nil, newValue, changes)
+		}
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
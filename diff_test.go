@@ -0,0 +1,42 @@
+package thecompaniesapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func testCompany(t *testing.T, body string) Company {
+	t.Helper()
+	var company Company
+	if err := json.Unmarshal([]byte(body), &company); err != nil {
+		t.Fatalf("Failed to unmarshal company: %v", err)
+	}
+	return company
+}
+
+func TestDiffCompanies(t *testing.T) {
+	old := testCompany(t, `{
+		"about": {"name": "Acme", "totalEmployees": "11-50", "industries": ["software"]},
+		"domain": {"domain": "acme.com"},
+		"finances": {"revenue": "1m-10m"}
+	}`)
+	current := testCompany(t, `{
+		"about": {"name": "Acme", "totalEmployees": "51-200", "industries": ["software", "saas"], "yearFounded": 2012},
+		"domain": {"domain": "acme.com"}
+	}`)
+
+	expected := []FieldChange{
+		{Path: "about.industries", Old: []any{"software"}, New: []any{"software", "saas"}},
+		{Path: "about.totalEmployees", Old: "11-50", New: "51-200"},
+		{Path: "about.yearFounded", Old: nil, New: float64(2012)},
+		{Path: "finances", Old: map[string]any{"revenue": "1m-10m"}, New: nil},
+	}
+	if changes := DiffCompanies(old, current); !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, changes)
+	}
+
+	if changes := DiffCompanies(old, old); len(changes) != 0 {
+		t.Errorf("Expected no change between identical snapshots, got %+v", changes)
+	}
+}
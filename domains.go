@@ -0,0 +1,131 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// FetchCompaniesByDomains fetches the companies of a set of domains with searches on
+// the domain.domain attribute, the domains being the values of a single exactEquals
+// condition with the Or operator. Domains are cleaned with NormalizeDomains, inputs which are
+// not domains being ignored, and searched by batches of MaxSearchSize so that every
+// batch fits in one page. Domains without company are missing from the result.
+func (c *CompaniesAPIClient) FetchCompaniesByDomains(ctx context.Context, domains []string) ([]Company, error) {
+	clean, _ := NormalizeDomains(domains)
+
+	var companies []Company
+	for start := 0; start < len(clean); start += MaxSearchSize {
+		batch := clean[start:min(start+MaxSearchSize, len(clean))]
+		query, err := domainsCondition(batch)
+		if err != nil {
+			return nil, err
+		}
+		size := float32(len(batch))
+		response, err := c.SearchCompaniesPost(ctx, SearchCompaniesPostJSONRequestBody{
+			Query: &[]SegmentationCondition{query},
+			Size:  &size,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if response.StatusCode() != http.StatusOK || response.JSON200 == nil {
+			return nil, c.baseClient.responseError(response.HTTPResponse, response.Body)
+		}
+		companies = append(companies, response.JSON200.Companies...)
+	}
+	return companies, nil
+}
+
+// domainsCondition builds the condition matching any of the domains
+func domainsCondition(domains []string) (SegmentationCondition, error) {
+	condition := SegmentationCondition{
+		Attribute: SegmentationConditionAttributeDomainDomain,
+		Operator:  Or,
+		Sign:      ExactEquals,
+		Values:    make([]SegmentationCondition_Values_Item, len(domains)),
+	}
+	for i, domain := range domains {
+		if err := condition.Values[i].FromSegmentationConditionValues0(domain); err != nil {
+			return SegmentationCondition{}, fmt.Errorf("failed to build domain condition: %w", err)
+		}
+	}
+	return condition, nil
+}
+
+// NormalizeDomains cleans a list of domains before fetching them in bulk. URLs are
+// reduced to their lowercased host without "www." ("https://www.Apple.com/about"
+// becomes "apple.com") and email addresses to their domain. The clean domains are
+// deduplicated in their order of first appearance, while the inputs that are not
+// domains are returned unchanged in invalid.
+func NormalizeDomains(inputs []string) (clean []string, invalid []string) {
+	seen := make(map[string]bool, len(inputs))
+	for _, input := range inputs {
+		domain, ok := normalizeDomain(input)
+		if !ok {
+			invalid = append(invalid, input)
+			continue
+		}
+		if !seen[domain] {
+			seen[domain] = true
+			clean = append(clean, domain)
+		}
+	}
+	return clean, invalid
+}
+
+// normalizeDomain returns the lowercased domain without "www." of a domain, URL or
+// email address. ResolveCompany reads its identifiers with the same rules.
+func normalizeDomain(input string) (string, bool) {
+	input = strings.TrimSpace(input)
+	if isEmailAddress(input) {
+		domain, err := EmailDomain(input)
+		return domain, err == nil && validDomain(domain)
+	}
+	_, domain, ok := parseDomainURL(input)
+	return domain, ok
+}
+
+// isEmailAddress reports whether an input is to be read as an email address rather
+// than a URL: an email inside a URL path is part of the URL
+func isEmailAddress(input string) bool {
+	return strings.Contains(input, "@") && !strings.Contains(input, "/")
+}
+
+// parseDomainURL parses a domain or an http(s) URL, the scheme defaulting to https,
+// and returns the URL with its lowercased host without "www.". It reports false when
+// the URL has user info or its host is not a valid domain.
+func parseDomainURL(input string) (*url.URL, string, bool) {
+	rawURL := input
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.User != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return nil, "", false
+	}
+	domain := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	return parsed, domain, validDomain(domain)
+}
+
+// validDomain reports whether domain is made of at least two labels of letters,
+// digits and inner hyphens, the last one not being numeric
+func validDomain(domain string) bool {
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+		for _, r := range label {
+			if r != '-' && !('a' <= r && r <= 'z') && !('0' <= r && r <= '9') && r < 0x80 {
+				return false
+			}
+		}
+	}
+	return strings.Trim(labels[len(labels)-1], "0123456789") != ""
+}
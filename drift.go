@@ -0,0 +1,104 @@
+package thecompaniesapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var (
+	responseTypesOnce sync.Once
+	responseTypes     map[string]reflect.Type
+)
+
+// loadResponseTypes indexes the generated type of the 200 response of every operation
+// by operation name, as declared by the methods of ClientWithResponsesInterface
+func loadResponseTypes() map[string]reflect.Type {
+	responseTypesOnce.Do(func() {
+		responseTypes = map[string]reflect.Type{}
+		client := reflect.TypeOf((*ClientWithResponsesInterface)(nil)).Elem()
+		for i := 0; i < client.NumMethod(); i++ {
+			method := client.Method(i)
+			name, ok := strings.CutSuffix(method.Name, "WithResponse")
+			if !ok || strings.HasSuffix(name, "WithBody") || method.Type.NumOut() == 0 {
+				continue
+			}
+			response := method.Type.Out(0)
+			if response.Kind() != reflect.Pointer || response.Elem().Kind() != reflect.Struct {
+				continue
+			}
+			if field, ok := response.Elem().FieldByName("JSON200"); ok && field.Type.Kind() == reflect.Pointer {
+				responseTypes[name] = field.Type.Elem()
+			}
+		}
+	})
+	return responseTypes
+}
+
+// WithSchemaDriftDetection logs with logger the fields of API responses that are
+// unknown to the generated types, giving an early warning that the API evolved past
+// the version of the SDK. Successful JSON responses are decoded a second time with
+// DisallowUnknownFields and the first unknown field of each response is logged at the
+// Warn level with its operation, once per operation and field. Responses are returned
+// to the caller unchanged.
+//
+// Detection buffers and decodes every response, so it is meant as a debug mode during
+// development, like WithRequestValidation.
+func WithSchemaDriftDetection(logger *slog.Logger) BaseClientOption {
+	return func(c *BaseClient) {
+		var reported sync.Map
+		c.middlewares = append(c.middlewares, func(next roundTripFunc) roundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				resp, err := next(req)
+				if err != nil || resp.StatusCode != http.StatusOK || !isJSONContentType(resp.Header.Get("Content-Type")) {
+					return resp, err
+				}
+				operation := requestOperationName(req)
+				responseType, ok := loadResponseTypes()[operation]
+				if !ok {
+					return resp, nil
+				}
+
+				body, err := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				resp.Body = io.NopCloser(bytes.NewReader(body))
+				if err != nil {
+					return resp, nil
+				}
+				field, ok := unknownResponseField(body, responseType)
+				if !ok {
+					return resp, nil
+				}
+				if _, seen := reported.LoadOrStore(operation+" "+field, true); !seen {
+					logger.LogAttrs(req.Context(), slog.LevelWarn, "unknown response field",
+						slog.String("operation", operation),
+						slog.String("field", field),
+					)
+				}
+				return resp, nil
+			}
+		})
+	}
+}
+
+// unknownResponseField decodes body into a value of responseType with
+// DisallowUnknownFields, returning the name of the first field the type does not declare
+func unknownResponseField(body []byte, responseType reflect.Type) (string, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(reflect.New(responseType).Interface())
+	if err == nil {
+		return "", false
+	}
+	// The json package reports unknown fields as `json: unknown field "name"`
+	field, ok := strings.CutPrefix(err.Error(), "json: unknown field ")
+	if !ok {
+		return "", false
+	}
+	return strings.Trim(field, `"`), true
+}
@@ -0,0 +1,61 @@
+package thecompaniesapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithSchemaDriftDetection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v2/companies/count" {
+			w.Write([]byte(`{"count":42}`))
+			return
+		}
+		w.Write([]byte(`{"about":{"name":"Apple"},"fundingRounds":[{"amount":10}]}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	client, err := ApiClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithSchemaDriftDetection(slog.New(slog.NewJSONHandler(&logs, nil))),
+	)
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		response, err := client.FetchCompany(ctx, "apple.com", nil)
+		if err != nil {
+			t.Fatalf("FetchCompany returned error: %v", err)
+		}
+		// The response is still decoded for the caller
+		if response.JSON200 == nil || response.JSON200.Name() != "Apple" {
+			t.Fatalf("Expected the company to be decoded, got %s", response.Body)
+		}
+	}
+	if _, err := client.CountCompanies(ctx, &CountCompaniesParams{}); err != nil {
+		t.Fatalf("CountCompanies returned error: %v", err)
+	}
+
+	// The unknown field is reported once
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("Expected a single log, got %q", logs.String())
+	}
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("Failed to decode log: %v", err)
+	}
+	if entry["level"] != "WARN" || entry["operation"] != "FetchCompany" || entry["field"] != "fundingRounds" {
+		t.Errorf("Unexpected log: %v", entry)
+	}
+}
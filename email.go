@@ -0,0 +1,126 @@
+package thecompaniesapi
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// ErrInvalidEmail is returned before any network call when an email address is malformed
+var ErrInvalidEmail = errors.New("invalid email address")
+
+// roleEmailLocalParts lists the local parts of shared mailboxes, which identify a
+// company but not a person
+var roleEmailLocalParts = map[string]bool{
+	"admin": true, "billing": true, "contact": true, "hello": true, "help": true,
+	"hr": true, "info": true, "jobs": true, "marketing": true, "no-reply": true,
+	"noreply": true, "office": true, "press": true, "sales": true, "support": true,
+	"team": true,
+}
+
+// ValidateEmail checks that email is a bare address (no display name) with a local
+// part and a fully qualified domain. Errors match ErrInvalidEmail.
+func ValidateEmail(email string) error {
+	_, _, err := splitEmail(email)
+	return err
+}
+
+// EmailDomain returns the lowercased domain of an email address, for instance to fall
+// back to FetchCompany when FetchCompanyByEmail does not find a company
+func EmailDomain(email string) (string, error) {
+	_, domain, err := splitEmail(email)
+	return domain, err
+}
+
+// IsRoleEmail reports whether email is a valid address of a shared mailbox such as
+// sales@ or info@
+func IsRoleEmail(email string) bool {
+	local, _, err := splitEmail(email)
+	return err == nil && roleEmailLocalParts[strings.ToLower(local)]
+}
+
+// NewFetchCompanyByEmailParams builds the params of FetchCompanyByEmail after
+// validating the email address
+func NewFetchCompanyByEmailParams(email string) (*FetchCompanyByEmailParams, error) {
+	if err := ValidateEmail(email); err != nil {
+		return nil, err
+	}
+	return &FetchCompanyByEmailParams{Email: email}, nil
+}
+
+// splitEmail validates an email address and returns its local part and lowercased domain
+func splitEmail(email string) (local, domain string, err error) {
+	address, parseErr := mail.ParseAddress(email)
+	if parseErr != nil || address.Name != "" || address.Address != email {
+		return "", "", fmt.Errorf("%w: %q", ErrInvalidEmail, email)
+	}
+
+	at := strings.LastIndex(email, "@")
+	local, domain = email[:at], strings.ToLower(email[at+1:])
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return "", "", fmt.Errorf("%w: %q has no top-level domain", ErrInvalidEmail, email)
+	}
+	for _, label := range labels {
+		if label == "" || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") || strings.HasPrefix(label, "[") {
+			return "", "", fmt.Errorf("%w: %q has an invalid domain", ErrInvalidEmail, email)
+		}
+	}
+	return local, domain, nil
+}
+
+// ErrNoEmailInfo is returned by FetchCompanyByEmailResponse.EmailInfo when the response
+// carries no email metadata, for instance an unsuccessful response
+var ErrNoEmailInfo = errors.New("response has no email metadata")
+
+// EmailInfo is the metadata of the email address looked up by FetchCompanyByEmail
+type EmailInfo struct {
+	Address string
+	// Domain is the domain of the address, e.g. "apple.com"
+	Domain string
+	// Name is the local part of the address
+	Name       string
+	FirstName  string
+	MiddleName string
+	LastName   string
+	// Pattern is the pattern of the address among the email patterns of the company,
+	// e.g. "{first}.{last}"
+	Pattern string
+
+	// IsValid reports whether the address is deliverable
+	IsValid       bool
+	IsValidFormat bool
+	IsDisposable  bool
+	// IsFree reports whether the address belongs to a free email provider
+	IsFree       bool
+	IsSubaddress bool
+	// IsRole reports whether the address is a shared mailbox such as sales@, as
+	// detected client-side by IsRoleEmail since the API does not report it
+	IsRole bool
+}
+
+// EmailInfo returns the email metadata of the response. It returns ErrNoEmailInfo when
+// the response was not successful.
+func (r *FetchCompanyByEmailResponse) EmailInfo() (*EmailInfo, error) {
+	if r == nil || r.JSON200 == nil {
+		return nil, ErrNoEmailInfo
+	}
+	email := r.JSON200.Email
+	info := &EmailInfo{
+		Address:       stringValue(email.Address),
+		Domain:        email.Domain,
+		Name:          email.Name,
+		FirstName:     stringValue(email.FullName.First),
+		MiddleName:    stringValue(email.FullName.Middle),
+		LastName:      stringValue(email.FullName.Last),
+		Pattern:       stringValue(email.Pattern),
+		IsValid:       email.IsValid,
+		IsValidFormat: email.IsValidFormat,
+		IsDisposable:  email.IsDisposable,
+		IsFree:        email.IsFree,
+		IsSubaddress:  email.IsSubaddress,
+	}
+	info.IsRole = IsRoleEmail(info.Address)
+	return info, nil
+}
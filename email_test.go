@@ -0,0 +1,132 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidateEmail(t *testing.T) {
+	valid := map[string]string{
+		"jane.doe@apple.com":         "apple.com",
+		"Jane+news@Mail.Example.org": "mail.example.org",
+		"sales@thecompaniesapi.com":  "thecompaniesapi.com",
+	}
+	for email, expectedDomain := range valid {
+		domain, err := EmailDomain(email)
+		if err != nil {
+			t.Errorf("Expected %q to be valid, got %v", email, err)
+		}
+		if domain != expectedDomain {
+			t.Errorf("Expected domain %q for %q, got %q", expectedDomain, email, domain)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"invalid-email-format",
+		"@apple.com",
+		"jane@",
+		"jane@localhost",
+		"jane@apple..com",
+		"jane@-apple.com",
+		"Jane Doe <jane@apple.com>",
+		"jane doe@apple.com",
+		" jane@apple.com",
+	}
+	for _, email := range invalid {
+		if err := ValidateEmail(email); !errors.Is(err, ErrInvalidEmail) {
+			t.Errorf("Expected ErrInvalidEmail for %q, got %v", email, err)
+		}
+		if _, err := NewFetchCompanyByEmailParams(email); !errors.Is(err, ErrInvalidEmail) {
+			t.Errorf("Expected NewFetchCompanyByEmailParams to reject %q, got %v", email, err)
+		}
+	}
+}
+
+func TestIsRoleEmail(t *testing.T) {
+	for email, expected := range map[string]bool{
+		"sales@apple.com":    true,
+		"Info@apple.com":     true,
+		"no-reply@apple.com": true,
+		"jane@apple.com":     false,
+		"sales@":             false,
+	} {
+		if IsRoleEmail(email) != expected {
+			t.Errorf("Expected IsRoleEmail(%q) to be %v", email, expected)
+		}
+	}
+}
+
+func TestFetchCompanyByEmailValidation(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	_, err = client.FetchCompanyByEmail(context.Background(), &FetchCompanyByEmailParams{Email: "invalid-email-format"})
+	if !errors.Is(err, ErrInvalidEmail) {
+		t.Errorf("Expected ErrInvalidEmail, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("Expected no request for an invalid email, got %d", requests)
+	}
+
+	if _, err := client.FetchCompanyByEmail(context.Background(), &FetchCompanyByEmailParams{Email: "jane@apple.com"}); err != nil {
+		t.Errorf("FetchCompanyByEmail returned error: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request, got %d", requests)
+	}
+}
+
+func TestFetchCompanyByEmailInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("email") == "unknown@nowhere.com" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":"not_found","message":"Not found"}`))
+			return
+		}
+		w.Write([]byte(`{"email":{"address":"sales@apple.com","domain":"apple.com","name":"sales","fullName":{"first":"Sales"},"isValid":true,"isValidFormat":true,"isFree":false,"isDisposable":false,"isSubaddress":true}}`))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	response, err := client.FetchCompanyByEmail(context.Background(), &FetchCompanyByEmailParams{Email: "sales@apple.com"})
+	if err != nil {
+		t.Fatalf("FetchCompanyByEmail returned error: %v", err)
+	}
+	info, err := response.EmailInfo()
+	if err != nil {
+		t.Fatalf("EmailInfo returned error: %v", err)
+	}
+	if info.Domain != "apple.com" || info.Address != "sales@apple.com" || info.FirstName != "Sales" || info.LastName != "" {
+		t.Errorf("Unexpected email info: %+v", info)
+	}
+	if !info.IsValid || !info.IsValidFormat || !info.IsSubaddress || info.IsFree || info.IsDisposable || !info.IsRole {
+		t.Errorf("Unexpected email flags: %+v", info)
+	}
+
+	response, err = client.FetchCompanyByEmail(context.Background(), &FetchCompanyByEmailParams{Email: "unknown@nowhere.com"})
+	if err != nil {
+		t.Fatalf("FetchCompanyByEmail returned error: %v", err)
+	}
+	if _, err := response.EmailInfo(); !errors.Is(err, ErrNoEmailInfo) {
+		t.Errorf("Expected ErrNoEmailInfo, got %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// EndpointInfo describes an operation of the OpenAPI specification
+type EndpointInfo struct {
+	Method      string
+	Path        string
+	OperationID string
+	Summary     string
+	Parameters  []EndpointParameter
+}
+
+// EndpointParameter is a parameter of an endpoint
+type EndpointParameter struct {
+	Name string
+	// In is the location of the parameter: "path", "query", "header" or "cookie"
+	In       string
+	Required bool
+}
+
+// ListEndpoints fetches the live OpenAPI specification with FetchOpenApi and lists its
+// endpoints with ParseEndpoints, e.g. to build a command line interface over the SDK
+// that follows the API rather than the version of the SDK.
+func (c *CompaniesAPIClient) ListEndpoints(ctx context.Context) ([]EndpointInfo, error) {
+	response, err := c.FetchOpenApi(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() != http.StatusOK {
+		return nil, c.baseClient.responseError(response.HTTPResponse, response.Body)
+	}
+	return ParseEndpoints(response.Body)
+}
+
+// ParseEndpoints lists the endpoints of a JSON or YAML OpenAPI specification, sorted by
+// path and method. The parameters shared by every operation of a path are listed with
+// the operation parameters, which override them.
+func ParseEndpoints(spec []byte) ([]EndpointInfo, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI specification: %w", err)
+	}
+	if doc.Paths == nil {
+		return nil, nil
+	}
+
+	var endpoints []EndpointInfo
+	for path, item := range doc.Paths.Map() {
+		for method, operation := range item.Operations() {
+			endpoints = append(endpoints, EndpointInfo{
+				Method:      method,
+				Path:        path,
+				OperationID: operation.OperationID,
+				Summary:     operation.Summary,
+				Parameters:  endpointParameters(item.Parameters, operation.Parameters),
+			})
+		}
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].Path != endpoints[j].Path {
+			return endpoints[i].Path < endpoints[j].Path
+		}
+		return endpoints[i].Method < endpoints[j].Method
+	})
+	return endpoints, nil
+}
+
+// endpointParameters merges the parameters of a path and of one of its operations
+func endpointParameters(pathParameters, operationParameters openapi3.Parameters) []EndpointParameter {
+	var parameters []EndpointParameter
+	for _, ref := range operationParameters {
+		if ref != nil && ref.Value != nil {
+			parameters = append(parameters, EndpointParameter{Name: ref.Value.Name, In: ref.Value.In, Required: ref.Value.Required})
+		}
+	}
+	for _, ref := range pathParameters {
+		if ref == nil || ref.Value == nil || operationParameters.GetByInAndName(ref.Value.In, ref.Value.Name) != nil {
+			continue
+		}
+		parameters = append(parameters, EndpointParameter{Name: ref.Value.Name, In: ref.Value.In, Required: ref.Value.Required})
+	}
+	return parameters
+}
@@ -0,0 +1,66 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+const sampleSpec = `{
+	"openapi": "3.0.0",
+	"info": {"title": "The Companies API", "version": "2.0.0"},
+	"paths": {
+		"/v2/companies/{domain}": {
+			"parameters": [{"name": "domain", "in": "path", "required": true, "schema": {"type": "string"}}],
+			"get": {
+				"operationId": "fetchCompany",
+				"summary": "Fetch a company",
+				"parameters": [{"name": "simplified", "in": "query", "schema": {"type": "boolean"}}],
+				"responses": {"200": {"description": "OK"}}
+			}
+		},
+		"/v2/companies/count": {
+			"get": {"operationId": "countCompanies", "responses": {"200": {"description": "OK"}}},
+			"post": {"operationId": "countCompaniesPost", "responses": {"200": {"description": "OK"}}}
+		}
+	}
+}`
+
+func TestListEndpoints(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/openapi" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(sampleSpec))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+	endpoints, err := client.ListEndpoints(context.Background())
+	if err != nil {
+		t.Fatalf("ListEndpoints returned error: %v", err)
+	}
+
+	expected := []EndpointInfo{
+		{Method: "GET", Path: "/v2/companies/count", OperationID: "countCompanies"},
+		{Method: "POST", Path: "/v2/companies/count", OperationID: "countCompaniesPost"},
+		{Method: "GET", Path: "/v2/companies/{domain}", OperationID: "fetchCompany", Summary: "Fetch a company", Parameters: []EndpointParameter{
+			{Name: "simplified", In: "query"},
+			{Name: "domain", In: "path", Required: true},
+		}},
+	}
+	if !reflect.DeepEqual(endpoints, expected) {
+		t.Errorf("Unexpected endpoints:\n%+v\n%+v", endpoints, expected)
+	}
+
+	if _, err := ParseEndpoints([]byte(`{"openapi":`)); err == nil {
+		t.Error("Expected an error for an invalid specification")
+	}
+}
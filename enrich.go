@@ -0,0 +1,73 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// EnrichedCompany combines the responses of the calls made by EnrichCompany. A field
+// is nil when its call failed.
+type EnrichedCompany struct {
+	Company       *FetchCompanyResponse
+	Context       *FetchCompanyContextResponse
+	EmailPatterns *FetchCompanyEmailPatternsResponse
+}
+
+// EnrichCompany concurrently calls FetchCompany, FetchCompanyContext and
+// FetchCompanyEmailPatterns for a domain. A failed call does not cancel the others:
+// the result always holds the successful responses and the error, when not nil, joins
+// the errors of the failed calls so that errors.Is and errors.As match any of them.
+// The calls share a single deadline: the one of ctx or else the default request timeout.
+func (c *CompaniesAPIClient) EnrichCompany(ctx context.Context, domain string) (*EnrichedCompany, error) {
+	ctx, cancel := c.newCallBudget(ctx, 1).next(ctx)
+	defer cancel()
+
+	var (
+		wg     sync.WaitGroup
+		result EnrichedCompany
+		errs   [3]error
+	)
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		response, err := c.FetchCompany(ctx, domain, nil)
+		if err == nil && response.StatusCode() != http.StatusOK {
+			err = c.baseClient.responseError(response.HTTPResponse, response.Body)
+		}
+		if err != nil {
+			errs[0] = fmt.Errorf("fetch company: %w", err)
+			return
+		}
+		result.Company = response
+	}()
+	go func() {
+		defer wg.Done()
+		response, err := c.FetchCompanyContext(ctx, domain)
+		if err == nil && response.StatusCode() != http.StatusOK {
+			err = c.baseClient.responseError(response.HTTPResponse, response.Body)
+		}
+		if err != nil {
+			errs[1] = fmt.Errorf("fetch company context: %w", err)
+			return
+		}
+		result.Context = response
+	}()
+	go func() {
+		defer wg.Done()
+		response, err := c.FetchCompanyEmailPatterns(ctx, domain, nil)
+		if err == nil && response.StatusCode() != http.StatusOK {
+			err = c.baseClient.responseError(response.HTTPResponse, response.Body)
+		}
+		if err != nil {
+			errs[2] = fmt.Errorf("fetch company email patterns: %w", err)
+			return
+		}
+		result.EmailPatterns = response
+	}()
+	wg.Wait()
+
+	return &result, errors.Join(errs[:]...)
+}
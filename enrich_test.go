@@ -0,0 +1,54 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEnrichCompany(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/context"):
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error":"internal","message":"Context unavailable"}`))
+		case strings.HasSuffix(r.URL.Path, "/email-patterns"):
+			w.Write([]byte(`[{"pattern":"{first}.{last}"}]`))
+		default:
+			w.Write([]byte(`{"domain":{"domain":"apple.com"}}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	result, err := client.EnrichCompany(context.Background(), "apple.com")
+	if result == nil {
+		t.Fatal("Expected partial result")
+	}
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("Expected the context error, got %v", err)
+	}
+	if !strings.HasPrefix(err.Error(), "fetch company context: ") {
+		t.Errorf("Expected error to name the failed call, got %q", err)
+	}
+
+	// The failed call does not lose the others
+	if result.Context != nil {
+		t.Errorf("Expected no context, got %+v", result.Context)
+	}
+	if result.Company == nil || result.Company.JSON200 == nil {
+		t.Error("Expected company response")
+	}
+	if result.EmailPatterns == nil || result.EmailPatterns.JSON200 == nil || len(*result.EmailPatterns.JSON200) != 1 {
+		t.Error("Expected email patterns response")
+	}
+}
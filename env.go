@@ -0,0 +1,43 @@
+package thecompaniesapi
+
+import (
+	"errors"
+	"os"
+)
+
+// Environment variables read by FromEnv and WithEnvironment
+const (
+	EnvAPIToken  = "TCA_API_TOKEN"
+	EnvAPIURL    = "TCA_API_URL"
+	EnvVisitorID = "TCA_VISITOR_ID"
+)
+
+// EnvInsecureSkipVerify must be set to "true" for WithInsecureSkipVerify to apply
+const EnvInsecureSkipVerify = "TCA_INSECURE_SKIP_VERIFY"
+
+// ErrMissingAPIToken is returned by FromEnv when TCA_API_TOKEN is not set
+var ErrMissingAPIToken = errors.New(EnvAPIToken + " is not set")
+
+// WithEnvironment applies the base URL and visitor ID set in the TCA_API_URL and
+// TCA_VISITOR_ID environment variables. Unset variables leave the configuration
+// unchanged, and options passed after WithEnvironment take precedence.
+func WithEnvironment() BaseClientOption {
+	return func(c *BaseClient) {
+		if baseURL := os.Getenv(EnvAPIURL); baseURL != "" {
+			c.baseURL = baseURL
+		}
+		if visitorID := os.Getenv(EnvVisitorID); visitorID != "" {
+			c.visitorID = visitorID
+		}
+	}
+}
+
+// FromEnv creates a client authenticated with the TCA_API_TOKEN environment variable
+// and configured with WithEnvironment, the options being applied afterwards
+func FromEnv(options ...BaseClientOption) (*CompaniesAPIClient, error) {
+	token := os.Getenv(EnvAPIToken)
+	if token == "" {
+		return nil, ErrMissingAPIToken
+	}
+	return ApiClient(token, append([]BaseClientOption{WithEnvironment()}, options...)...)
+}
@@ -0,0 +1,61 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFromEnv(t *testing.T) {
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	t.Setenv(EnvAPIToken, "env-api-key")
+	t.Setenv(EnvAPIURL, server.URL)
+	t.Setenv(EnvVisitorID, "env-visitor")
+
+	client, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv returned error: %v", err)
+	}
+	if client.BaseURL() != server.URL {
+		t.Errorf("Expected base URL %s, got %s", server.URL, client.BaseURL())
+	}
+	if _, err := client.FetchApiHealth(context.Background()); err != nil {
+		t.Fatalf("FetchApiHealth returned error: %v", err)
+	}
+	if headers.Get("Authorization") != "Basic env-api-key" || headers.Get("Tca-Visitor-Id") != "env-visitor" {
+		t.Errorf("Expected the environment to be applied, got %v", headers)
+	}
+
+	// Explicit options override the environment
+	client, err = FromEnv(WithVisitorID("explicit-visitor"))
+	if err != nil {
+		t.Fatalf("FromEnv returned error: %v", err)
+	}
+	if client.baseClient.visitorID != "explicit-visitor" {
+		t.Errorf("Expected the explicit visitor ID, got %s", client.baseClient.visitorID)
+	}
+
+	t.Setenv(EnvAPIToken, "")
+	if _, err := FromEnv(); !errors.Is(err, ErrMissingAPIToken) {
+		t.Errorf("Expected ErrMissingAPIToken, got %v", err)
+	}
+}
+
+func TestWithEnvironmentUnset(t *testing.T) {
+	t.Setenv(EnvAPIURL, "")
+	t.Setenv(EnvVisitorID, "")
+
+	client := NewBaseClient("test-api-key", WithCustomBaseURL("https://custom.example.com"), WithEnvironment())
+	if client.BaseURL() != "https://custom.example.com" || client.visitorID != "" {
+		t.Errorf("Expected unset variables to leave the configuration unchanged, got %s %q", client.BaseURL(), client.visitorID)
+	}
+}
@@ -0,0 +1,24 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// CompanyExists cheaply checks whether a company is known for the domain with a HEAD
+// request, without fetching its payload. A 404 response reports false; any other
+// unsuccessful response is returned as an error.
+func (c *CompaniesAPIClient) CompanyExists(ctx context.Context, domain string) (bool, error) {
+	_, err := c.baseClient.makeRequestWithEditors(ctx, http.MethodHead, "/v2/companies/"+url.PathEscape(domain), nil, c.scope)
+	if err == nil {
+		return true, nil
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	return false, err
+}
@@ -0,0 +1,45 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCompanyExists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("Expected HEAD request, got %s", r.Method)
+		}
+		switch r.URL.Path {
+		case "/v2/companies/apple.com":
+			w.WriteHeader(http.StatusOK)
+		case "/v2/companies/unknown.com":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+	ctx := context.Background()
+
+	if exists, err := client.CompanyExists(ctx, "apple.com"); err != nil || !exists {
+		t.Errorf("Expected apple.com to exist, got %v, %v", exists, err)
+	}
+	if exists, err := client.CompanyExists(ctx, "unknown.com"); err != nil || exists {
+		t.Errorf("Expected unknown.com not to exist, got %v, %v", exists, err)
+	}
+
+	_, err = client.CompanyExists(ctx, "broken.com")
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected HTTP 500 error, got %v", err)
+	}
+}
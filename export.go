@@ -0,0 +1,200 @@
+package thecompaniesapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ErrNoExportFields is returned by ExportListToCSV when no field is requested
+var ErrNoExportFields = errors.New("no fields to export")
+
+// StreamSearchCompaniesNDJSON writes every company matching params to w as
+// newline-delimited JSON (one company per line), paginating through the results.
+// Output is flushed after every page, including to w when it is an http.Flusher,
+// and the iteration stops as soon as ctx is done. It returns the number of
+// companies written, even when an error interrupts the export.
+func (c *CompaniesAPIClient) StreamSearchCompaniesNDJSON(ctx context.Context, params *SearchCompaniesParams, w io.Writer) (int, error) {
+	buffered := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buffered)
+
+	written := 0
+	err := c.forEachSearchCompaniesPage(ctx, params, func(companies []CompanyV2, meta PaginationMeta) error {
+		for _, company := range companies {
+			if err := encoder.Encode(company); err != nil {
+				return fmt.Errorf("failed to write company: %w", err)
+			}
+			written++
+		}
+		return flushWriter(buffered, w)
+	})
+	if err != nil {
+		buffered.Flush()
+		return written, err
+	}
+	return written, flushWriter(buffered, w)
+}
+
+// ExportListToCSV writes the companies of a list to w as CSV, paginating through the
+// list. The header row holds the requested fields, dotted paths of the company schema
+// such as "domain.domain" or "about.name", and every company is written as a row of
+// their values: missing and null fields are left empty, strings, numbers and booleans
+// are written as is and lists or objects as JSON. Output is flushed after every page
+// and the export stops as soon as ctx is done. It returns the number of companies
+// written, even when an error interrupts the export. Columns are named after their
+// field unless renamed with WithColumnNames.
+func (c *CompaniesAPIClient) ExportListToCSV(ctx context.Context, listId float32, w io.Writer, fields []string, options ...ExportOption) (int, error) {
+	if len(fields) == 0 {
+		return 0, ErrNoExportFields
+	}
+	config := newExportConfig(options)
+
+	header := make([]string, len(fields))
+	for i, field := range fields {
+		header[i] = config.columnName(field)
+	}
+	buffered := bufio.NewWriter(w)
+	writer := csv.NewWriter(buffered)
+	if err := writer.Write(header); err != nil {
+		return 0, fmt.Errorf("failed to write header: %w", err)
+	}
+
+	written := 0
+	row := make([]string, len(fields))
+	err := c.forEachCompaniesInListPage(ctx, listId, nil, func(companies []Company, meta PaginationMeta) error {
+		for _, company := range companies {
+			values := companyValues(company)
+			for i, field := range fields {
+				row[i] = formatFieldValue(fieldValue(values, field))
+			}
+			if err := writer.Write(row); err != nil {
+				return fmt.Errorf("failed to write company: %w", err)
+			}
+			written++
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return fmt.Errorf("failed to write company: %w", err)
+		}
+		return flushWriter(buffered, w)
+	})
+	writer.Flush()
+	if err != nil {
+		buffered.Flush()
+		return written, err
+	}
+	if err := writer.Error(); err != nil {
+		return written, fmt.Errorf("failed to write company: %w", err)
+	}
+	return written, flushWriter(buffered, w)
+}
+
+// ExportOption configures the output of ExportListToCSV and Company.ToFlatMap
+type ExportOption func(*exportConfig)
+
+// exportConfig holds the export options
+type exportConfig struct {
+	columnNames map[string]string
+}
+
+func newExportConfig(options []ExportOption) *exportConfig {
+	config := &exportConfig{}
+	for _, option := range options {
+		option(config)
+	}
+	return config
+}
+
+// columnName returns the name of the column of a field
+func (c *exportConfig) columnName(field string) string {
+	if name, ok := c.columnNames[field]; ok {
+		return name
+	}
+	return field
+}
+
+// WithColumnNames renames the columns of an export, names mapping dotted field paths
+// to the names expected downstream, e.g. {"about.name": "Company Name"}. Fields
+// missing from names keep their path.
+func WithColumnNames(names map[string]string) ExportOption {
+	return func(c *exportConfig) {
+		c.columnNames = names
+	}
+}
+
+// ToFlatMap flattens the company to a map keyed by the dotted paths of its fields
+// ("about.name", "domain.domain"...), nested objects being flattened and lists kept as
+// values. Null fields are left out. Keys can be renamed with WithColumnNames.
+func (c Company) ToFlatMap(options ...ExportOption) map[string]any {
+	config := newExportConfig(options)
+	flat := map[string]any{}
+	flattenValues("", companyValues(c), func(path string, value any) {
+		flat[config.columnName(path)] = value
+	})
+	return flat
+}
+
+// flattenValues calls fn with the dotted path and value of every non-null leaf
+func flattenValues(path string, value any, fn func(path string, value any)) {
+	switch v := value.(type) {
+	case nil:
+	case map[string]any:
+		for key, nested := range v {
+			flattenValues(joinPath(path, key), nested, fn)
+		}
+	default:
+		fn(path, v)
+	}
+}
+
+// fieldValue returns the value at a dotted path of JSON decoded values, nil when a
+// part of the path is missing or null
+func fieldValue(values map[string]any, path string) any {
+	var value any = values
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = object[key]
+	}
+	return value
+}
+
+// formatFieldValue formats a JSON decoded value as a CSV cell
+func formatFieldValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(encoded)
+	}
+}
+
+// flushWriter flushes the buffered output and the destination when it supports flushing
+func flushWriter(buffered *bufio.Writer, w io.Writer) error {
+	if err := buffered.Flush(); err != nil {
+		return fmt.Errorf("failed to flush output: %w", err)
+	}
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
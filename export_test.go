@@ -0,0 +1,196 @@
+package thecompaniesapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// newPaginatedCompaniesServer serves total companies split in pages of perPage items
+func newPaginatedCompaniesServer(t *testing.T, total, perPage int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < 1 {
+			page = 1
+		}
+		lastPage := (total + perPage - 1) / perPage
+
+		companies := []map[string]any{}
+		for i := (page - 1) * perPage; i < page*perPage && i < total; i++ {
+			companies = append(companies, map[string]any{"domain": map[string]any{"domain": fmt.Sprintf("company-%d.com", i)}})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"companies": companies,
+			"meta":      map[string]any{"currentPage": page, "lastPage": lastPage, "perPage": perPage, "total": total},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestStreamSearchCompaniesNDJSON(t *testing.T) {
+	server := newPaginatedCompaniesServer(t, 25, 10)
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	var output bytes.Buffer
+	written, err := client.StreamSearchCompaniesNDJSON(context.Background(), &SearchCompaniesParams{}, &output)
+	if err != nil {
+		t.Fatalf("StreamSearchCompaniesNDJSON failed: %v", err)
+	}
+	if written != 25 {
+		t.Errorf("Expected 25 companies written, got %d", written)
+	}
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 25 {
+		t.Fatalf("Expected 25 lines, got %d", len(lines))
+	}
+	var company CompanyV2
+	if err := json.Unmarshal([]byte(lines[24]), &company); err != nil || company.Domain.Domain != "company-24.com" {
+		t.Errorf("Unexpected last line %s (%v)", lines[24], err)
+	}
+}
+
+func TestStreamSearchCompaniesNDJSONCancelled(t *testing.T) {
+	server := newPaginatedCompaniesServer(t, 25, 10)
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var output bytes.Buffer
+	written, err := client.StreamSearchCompaniesNDJSON(ctx, nil, &output)
+	if err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if written != 0 || output.Len() != 0 {
+		t.Errorf("Expected nothing written, got %d companies", written)
+	}
+}
+
+func TestExportListToCSV(t *testing.T) {
+	pages := map[string]string{
+		"1": `{"companies":[{"domain":{"domain":"apple.com"},"about":{"name":"Apple","industries":["hardware","software"],"yearFounded":1976}},{"domain":{"domain":"stripe.com"},"about":null}],"meta":{"currentPage":1,"lastPage":2}}`,
+		"2": `{"companies":[{"domain":{"domain":"figma.com"},"about":{"name":"Figma, Inc."}}],"meta":{"currentPage":2,"lastPage":2}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/lists/7/companies" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(pages[r.URL.Query().Get("page")]))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	var output bytes.Buffer
+	fields := []string{"domain.domain", "about.name", "about.industries", "about.yearFounded", "finances.revenue"}
+	written, err := client.ExportListToCSV(context.Background(), 7, &output, fields)
+	if err != nil {
+		t.Fatalf("ExportListToCSV failed: %v", err)
+	}
+	if written != 3 {
+		t.Errorf("Expected 3 companies written, got %d", written)
+	}
+
+	records, err := csv.NewReader(&output).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read the CSV output: %v", err)
+	}
+	expected := [][]string{
+		fields,
+		{"apple.com", "Apple", `["hardware","software"]`, "1976", ""},
+		{"stripe.com", "", "", "", ""},
+		{"figma.com", "Figma, Inc.", "", "", ""},
+	}
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("Expected records %q, got %q", expected, records)
+	}
+
+	if _, err := client.ExportListToCSV(context.Background(), 7, &output, nil); err != ErrNoExportFields {
+		t.Errorf("Expected ErrNoExportFields, got %v", err)
+	}
+}
+
+func TestExportListToCSVCancelled(t *testing.T) {
+	server := newPaginatedCompaniesServer(t, 25, 10)
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var output bytes.Buffer
+	written, err := client.ExportListToCSV(ctx, 1, &cancellingWriter{Writer: &output, cancel: cancel}, []string{"domain.domain"})
+	if err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	// The first page is written before the cancellation stops the export
+	if written != 10 || strings.Count(output.String(), "\n") != 11 {
+		t.Errorf("Expected the header and 10 rows, got %d companies and %q", written, output.String())
+	}
+}
+
+// cancellingWriter cancels a context on its first write
+type cancellingWriter struct {
+	io.Writer
+	cancel context.CancelFunc
+}
+
+func (w *cancellingWriter) Write(p []byte) (int, error) {
+	w.cancel()
+	return w.Writer.Write(p)
+}
+
+func TestExportColumnNames(t *testing.T) {
+	server := newPaginatedCompaniesServer(t, 3, 10)
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	var output bytes.Buffer
+	names := WithColumnNames(map[string]string{"domain.domain": "Website"})
+	if _, err := client.ExportListToCSV(context.Background(), 1, &output, []string{"domain.domain", "about.name"}, names); err != nil {
+		t.Fatalf("ExportListToCSV failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 4 || lines[0] != "Website,about.name" || lines[1] != "company-0.com," {
+		t.Errorf("Unexpected CSV output: %q", lines)
+	}
+
+	company := testCompany(t, `{"domain":{"domain":"apple.com","tld":"com"},"about":{"name":"Apple","industries":["hardware"],"yearFounded":null}}`)
+	flat := company.ToFlatMap(WithColumnNames(map[string]string{"about.name": "Company Name"}))
+	expected := map[string]any{
+		"domain.domain":    "apple.com",
+		"domain.tld":       "com",
+		"Company Name":     "Apple",
+		"about.industries": []any{"hardware"},
+	}
+	if !reflect.DeepEqual(flat, expected) {
+		t.Errorf("Expected %v, got %v", expected, flat)
+	}
+}
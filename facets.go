@@ -0,0 +1,58 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"sync"
+)
+
+// FacetedSearchResult is the result of SearchCompaniesWithFacets: the search response
+// and the facet counts of its query
+type FacetedSearchResult struct {
+	*SearchCompaniesPostResponse
+	// Facets holds a breakdown per requested attribute, e.g. Facets.ByIndustry()
+	Facets *AnalyticsResult
+}
+
+// SearchCompaniesWithFacets runs SearchCompaniesPost and FetchCompaniesAnalyticsResult
+// concurrently to return the results and the facet counts of a filter UI in a single
+// call. The search API has no facets of its own: the counts are computed by the
+// analytics endpoint from the Query and ActionId of body, the free text Search being
+// ignored. attributes default to DefaultAnalyticsAttributes. The first error cancels
+// the other call and is returned.
+func (c *CompaniesAPIClient) SearchCompaniesWithFacets(ctx context.Context, body SearchCompaniesPostJSONRequestBody, attributes ...FetchCompaniesAnalyticsParamsAttribute) (*FacetedSearchResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	analyticsParams := &FetchCompaniesAnalyticsParams{Query: body.Query, ActionId: body.ActionId}
+
+	var (
+		wg        sync.WaitGroup
+		result    FacetedSearchResult
+		searchErr error
+		facetsErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		result.SearchCompaniesPostResponse, searchErr = c.SearchCompaniesPost(ctx, body)
+		if searchErr != nil {
+			cancel()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		result.Facets, facetsErr = c.FetchCompaniesAnalyticsResult(ctx, analyticsParams, attributes...)
+		if facetsErr != nil {
+			cancel()
+		}
+	}()
+	wg.Wait()
+
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	if facetsErr != nil {
+		return nil, facetsErr
+	}
+	return &result, nil
+}
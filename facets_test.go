@@ -0,0 +1,52 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSearchCompaniesWithFacets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v2/companies":
+			w.Write([]byte(`{"companies":[{"about":{"name":"Apple"}}],"meta":{"total":1}}`))
+		case "/v2/companies/analytics":
+			if !strings.Contains(r.URL.Query().Get("query"), `"attribute":"about.industries"`) {
+				t.Errorf("Expected the search query to be forwarded to the analytics")
+			}
+			w.Write([]byte(`{"data":[{"name":"` + r.URL.Query().Get("attribute") + `-bucket","count":42,"percentageOfAll":1,"percentageOfTotal":100}],"meta":{"query":[],"totalDatapoints":1,"totalDocuments":42,"totalValues":42}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	query := []SegmentationCondition{testCondition(SegmentationConditionAttributeAboutIndustries, And)}
+	result, err := client.SearchCompaniesWithFacets(context.Background(), SearchCompaniesPostJSONRequestBody{Query: &query})
+	if err != nil {
+		t.Fatalf("SearchCompaniesWithFacets returned error: %v", err)
+	}
+
+	if result.JSON200 == nil || len(result.JSON200.Companies) != 1 {
+		t.Errorf("Expected the search results, got %s", result.Body)
+	}
+	if bucket, ok := result.Facets.ByIndustry().Bucket("about.industry-bucket"); !ok || bucket.Count != 42 {
+		t.Errorf("Expected the industry facet count, got %+v", result.Facets.ByIndustry())
+	}
+	if result.Facets.ByCountry() == nil || result.Facets.BySize() == nil {
+		t.Error("Expected the country and size facets")
+	}
+
+	if _, err := client.SearchCompaniesWithFacets(context.Background(), SearchCompaniesPostJSONRequestBody{Query: &query}, FetchCompaniesAnalyticsParamsAttributeApps); err != nil {
+		t.Errorf("Expected facets for the apps attribute, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package thecompaniesapi
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ResponseHook transforms the raw body of a response before it is decoded. endpoint is
+// the operation ID of the request in the OpenAPI specification (e.g. "FetchCompany").
+type ResponseHook func(endpoint string, body []byte) ([]byte, error)
+
+// WithResponseHook runs hook on every response body received by the client, once it
+// is read and before it is unmarshaled, so that both MakeRequest and the typed
+// methods see the transformed payload. An error returned by the hook aborts the call.
+// Hooks registered several times run in registration order.
+func WithResponseHook(hook ResponseHook) BaseClientOption {
+	return func(c *BaseClient) {
+		c.middlewares = append(c.middlewares, func(next roundTripFunc) roundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				resp, err := next(req)
+				if err != nil {
+					return resp, err
+				}
+
+				body, err := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+
+				endpoint := requestOperationName(req)
+				body, err = hook(endpoint, body)
+				if err != nil {
+					return nil, fmt.Errorf("response hook for %s: %w", endpoint, err)
+				}
+
+				resp.Body = io.NopCloser(bytes.NewReader(body))
+				resp.ContentLength = int64(len(body))
+				resp.Header.Del("Content-Length")
+				return resp, nil
+			}
+		})
+	}
+}
+
+// WithRequestMutator runs mutate on every outgoing request once it is fully built,
+// after the authentication headers and every middleware, right before it is sent, e.g.
+// to sign it with an HMAC header. It applies to the typed methods and MakeRequest
+// alike, and runs again for every retry attempt. An error returned by mutate aborts
+// the request. Mutators registered several times run in registration order.
+func WithRequestMutator(mutate func(req *http.Request) error) BaseClientOption {
+	return func(c *BaseClient) {
+		c.requestMutators = append(c.requestMutators, mutate)
+	}
+}
+
+// mutateRequest wraps send to run the request mutators before it
+func (c *BaseClient) mutateRequest(send roundTripFunc) roundTripFunc {
+	if len(c.requestMutators) == 0 {
+		return send
+	}
+	return func(req *http.Request) (*http.Response, error) {
+		for _, mutate := range c.requestMutators {
+			if err := mutate(req); err != nil {
+				closeRequestBody(req.Body)
+				return nil, fmt.Errorf("request mutator: %w", err)
+			}
+		}
+		return send(req)
+	}
+}
@@ -0,0 +1,143 @@
+package thecompaniesapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithResponseHook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"domain":{"domain":"apple.com"},"about":{"name":"APPLE INC"}}`))
+	}))
+	defer server.Close()
+
+	var endpoints []string
+	client, err := ApiClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithResponseHook(func(endpoint string, body []byte) ([]byte, error) {
+			endpoints = append(endpoints, endpoint)
+			return bytes.ReplaceAll(body, []byte("APPLE INC"), []byte("Apple Inc.")), nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	response, err := client.FetchCompany(context.Background(), "apple.com", nil)
+	if err != nil {
+		t.Fatalf("FetchCompany returned error: %v", err)
+	}
+	if response.JSON200 == nil || response.JSON200.About == nil || response.JSON200.About.Name == nil {
+		t.Fatalf("Expected a decoded company, got %s", response.Body)
+	}
+	if name := *response.JSON200.About.Name; name != "Apple Inc." {
+		t.Errorf("Expected the hook to rewrite the name, got %q", name)
+	}
+	if len(endpoints) != 1 || endpoints[0] != "FetchCompany" {
+		t.Errorf("Expected the hook to receive FetchCompany, got %v", endpoints)
+	}
+}
+
+func TestWithResponseHookError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	errRejected := errors.New("rejected payload")
+	client := NewBaseClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithResponseHook(func(endpoint string, body []byte) ([]byte, error) {
+			return nil, errRejected
+		}),
+	)
+
+	if _, err := client.MakeRequest(context.Background(), "GET", "/v2/health", nil); !errors.Is(err, errRejected) {
+		t.Errorf("Expected the hook error to abort the call, got %v", err)
+	}
+}
+
+func TestWithRequestMutator(t *testing.T) {
+	sign := func(req *http.Request, body []byte) string {
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write([]byte(req.Method + " " + req.URL.Path + " " + req.Header.Get("Authorization") + " " + req.Header.Get("Tca-Api-Version") + " "))
+		mac.Write(body)
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get("X-Signature") != sign(r, body) {
+			t.Errorf("Unexpected signature for %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"count":42}`))
+	}))
+	defer server.Close()
+
+	var order []string
+	client, err := ApiClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithAPIVersion("2025-01-01"),
+		WithRequestMutator(func(req *http.Request) error {
+			order = append(order, "first")
+			return nil
+		}),
+		// Signs the request as sent, the client headers included
+		WithRequestMutator(func(req *http.Request) error {
+			order = append(order, "sign")
+			var body []byte
+			if req.GetBody != nil {
+				reader, err := req.GetBody()
+				if err != nil {
+					return err
+				}
+				body, _ = io.ReadAll(reader)
+			}
+			req.Header.Set("X-Signature", sign(req, body))
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	ctx := context.Background()
+	search := "saas"
+	if _, err := client.CountCompaniesPost(ctx, CountCompaniesPostJSONRequestBody{Search: &search}); err != nil {
+		t.Fatalf("CountCompaniesPost returned error: %v", err)
+	}
+	if _, err := client.baseClient.MakeRequest(ctx, http.MethodGet, "/v2/companies/count", nil); err != nil {
+		t.Fatalf("MakeRequest returned error: %v", err)
+	}
+	if hits != 2 || len(order) != 4 || order[0] != "first" || order[1] != "sign" {
+		t.Errorf("Expected the mutators to run in order for both requests, got %v", order)
+	}
+
+	// An error aborts the request
+	failure := errors.New("no signing key")
+	client, err = ApiClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithRequestMutator(func(req *http.Request) error { return failure }),
+	)
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+	if _, err := client.CountCompanies(ctx, &CountCompaniesParams{}); !errors.Is(err, failure) {
+		t.Errorf("Expected the mutator error, got %v", err)
+	}
+	if hits != 2 {
+		t.Errorf("Expected the request not to be sent, got %d requests", hits)
+	}
+}
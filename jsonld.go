@@ -0,0 +1,143 @@
+package thecompaniesapi
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// schemaOrgOrganization is the schema.org Organization JSON-LD document of a company
+type schemaOrgOrganization struct {
+	Context            string                  `json:"@context,omitempty"`
+	Type               string                  `json:"@type"`
+	Name               string                  `json:"name,omitempty"`
+	LegalName          string                  `json:"legalName,omitempty"`
+	AlternateName      []string                `json:"alternateName,omitempty"`
+	URL                string                  `json:"url,omitempty"`
+	Logo               string                  `json:"logo,omitempty"`
+	Image              string                  `json:"image,omitempty"`
+	Description        string                  `json:"description,omitempty"`
+	FoundingDate       string                  `json:"foundingDate,omitempty"`
+	NumberOfEmployees  *schemaOrgQuantity      `json:"numberOfEmployees,omitempty"`
+	Email              string                  `json:"email,omitempty"`
+	Telephone          string                  `json:"telephone,omitempty"`
+	Address            *schemaOrgPostalAddress `json:"address,omitempty"`
+	SameAs             []string                `json:"sameAs,omitempty"`
+	ParentOrganization *schemaOrgOrganization  `json:"parentOrganization,omitempty"`
+}
+
+// schemaOrgQuantity is a schema.org QuantitativeValue
+type schemaOrgQuantity struct {
+	Type     string `json:"@type"`
+	MinValue *int   `json:"minValue,omitempty"`
+	MaxValue *int   `json:"maxValue,omitempty"`
+}
+
+// schemaOrgPostalAddress is a schema.org PostalAddress
+type schemaOrgPostalAddress struct {
+	Type            string `json:"@type"`
+	AddressLocality string `json:"addressLocality,omitempty"`
+	AddressRegion   string `json:"addressRegion,omitempty"`
+	PostalCode      string `json:"postalCode,omitempty"`
+	AddressCountry  string `json:"addressCountry,omitempty"`
+}
+
+// ToSchemaOrgJSONLD encodes the company as a schema.org Organization JSON-LD document,
+// e.g. for a <script type="application/ld+json"> tag. It maps the name, website, logo,
+// description, founding date, employee count, first email and phone, headquarters
+// address, social profiles (as sameAs) and parent company. Missing fields are left out.
+func (c Company) ToSchemaOrgJSONLD() ([]byte, error) {
+	organization := schemaOrgOrganization{
+		Context:      "https://schema.org",
+		Type:         "Organization",
+		Name:         c.Name(),
+		URL:          domainURL(c.DomainName()),
+		Description:  c.Description(),
+		FoundingDate: c.foundingDate(),
+		Address:      c.schemaOrgAddress(),
+	}
+	if c.About != nil {
+		organization.LegalName = stringValue(c.About.NameLegal)
+		if c.About.NameAlts != nil {
+			organization.AlternateName = *c.About.NameAlts
+		}
+	}
+	if c.Assets != nil {
+		if c.Assets.LogoSquare != nil {
+			organization.Logo = stringValue(c.Assets.LogoSquare.Src)
+		}
+		if c.Assets.Cover != nil {
+			organization.Image = stringValue(c.Assets.Cover.Src)
+		}
+	}
+	if min, max, ok := c.EmployeeCount(); ok {
+		organization.NumberOfEmployees = &schemaOrgQuantity{Type: "QuantitativeValue", MinValue: &min}
+		if max != math.MaxInt {
+			organization.NumberOfEmployees.MaxValue = &max
+		}
+	}
+	if c.Contacts != nil {
+		if c.Contacts.Emails != nil && len(*c.Contacts.Emails) > 0 {
+			organization.Email = stringValue((*c.Contacts.Emails)[0].Value)
+		}
+		if c.Contacts.Phones != nil && len(*c.Contacts.Phones) > 0 {
+			organization.Telephone = stringValue((*c.Contacts.Phones)[0].Value)
+		}
+	}
+	for _, profile := range c.SocialProfiles() {
+		organization.SameAs = append(organization.SameAs, profile)
+	}
+	sort.Strings(organization.SameAs)
+	if c.Companies != nil && c.Companies.Parent != nil {
+		parent := schemaOrgOrganization{
+			Type: "Organization",
+			Name: stringValue(c.Companies.Parent.Name),
+			URL:  domainURL(stringValue(c.Companies.Parent.Domain)),
+		}
+		if parent.Name != "" || parent.URL != "" {
+			organization.ParentOrganization = &parent
+		}
+	}
+	return json.Marshal(organization)
+}
+
+// foundingDate returns the founding date of the company, its founding year when the
+// exact date is unknown
+func (c Company) foundingDate() string {
+	if c.About != nil && c.About.YearFoundedDate != nil && *c.About.YearFoundedDate != "" {
+		return *c.About.YearFoundedDate
+	}
+	if year := c.YearFounded(); year != 0 {
+		return strconv.Itoa(year)
+	}
+	return ""
+}
+
+// schemaOrgAddress returns the headquarters address of the company, nil when unknown
+func (c Company) schemaOrgAddress() *schemaOrgPostalAddress {
+	if c.Locations == nil || c.Locations.Headquarters == nil {
+		return nil
+	}
+	headquarters := c.Locations.Headquarters
+	address := schemaOrgPostalAddress{Type: "PostalAddress", AddressCountry: c.CountryCode()}
+	if headquarters.City != nil {
+		address.AddressLocality = stringValue(headquarters.City.Name)
+		address.PostalCode = stringValue(headquarters.City.Postcode)
+	}
+	if headquarters.State != nil {
+		address.AddressRegion = stringValue(headquarters.State.Name)
+	}
+	if address == (schemaOrgPostalAddress{Type: "PostalAddress"}) {
+		return nil
+	}
+	return &address
+}
+
+// domainURL returns the website URL of a domain, "" for an empty domain
+func domainURL(domain string) string {
+	if domain == "" {
+		return ""
+	}
+	return "https://" + domain
+}
@@ -0,0 +1,82 @@
+package thecompaniesapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestToSchemaOrgJSONLD(t *testing.T) {
+	company := testCompany(t, `{
+		"about": {"name": "Acme", "nameLegal": "Acme Inc.", "totalEmployees": "51-200", "yearFounded": 1999},
+		"domain": {"domain": "acme.com"},
+		"assets": {"logoSquare": {"src": "https://cdn.acme.com/logo.png"}},
+		"descriptions": {"primary": "Anvils and more"},
+		"contacts": {"emails": [{"value": "hello@acme.com"}]},
+		"locations": {"headquarters": {
+			"city": {"name": "San Francisco", "postcode": "94105"},
+			"state": {"name": "California"},
+			"country": {"code": "us"}
+		}},
+		"socials": {
+			"twitter": {"url": "https://twitter.com/acme"},
+			"linkedin": {"url": "https://linkedin.com/company/acme"}
+		},
+		"companies": {"parent": {"name": "Acme Holdings", "domain": "acmeholdings.com"}}
+	}`)
+
+	encoded, err := company.ToSchemaOrgJSONLD()
+	if err != nil {
+		t.Fatalf("ToSchemaOrgJSONLD returned error: %v", err)
+	}
+	var document map[string]any
+	if err := json.Unmarshal(encoded, &document); err != nil {
+		t.Fatalf("Expected valid JSON, got %v: %s", err, encoded)
+	}
+
+	for key, expected := range map[string]any{
+		"@context":     "https://schema.org",
+		"@type":        "Organization",
+		"name":         "Acme",
+		"legalName":    "Acme Inc.",
+		"url":          "https://acme.com",
+		"logo":         "https://cdn.acme.com/logo.png",
+		"description":  "Anvils and more",
+		"foundingDate": "1999",
+		"email":        "hello@acme.com",
+	} {
+		if document[key] != expected {
+			t.Errorf("Expected %s to be %v, got %v", key, expected, document[key])
+		}
+	}
+	sameAs, _ := document["sameAs"].([]any)
+	if len(sameAs) != 2 || sameAs[0] != "https://linkedin.com/company/acme" || sameAs[1] != "https://twitter.com/acme" {
+		t.Errorf("Unexpected sameAs: %v", document["sameAs"])
+	}
+	address, _ := document["address"].(map[string]any)
+	if address["@type"] != "PostalAddress" || address["addressLocality"] != "San Francisco" || address["postalCode"] != "94105" ||
+		address["addressRegion"] != "California" || address["addressCountry"] != "us" {
+		t.Errorf("Unexpected address: %v", document["address"])
+	}
+	employees, _ := document["numberOfEmployees"].(map[string]any)
+	if employees["@type"] != "QuantitativeValue" || employees["minValue"] != float64(51) || employees["maxValue"] != float64(200) {
+		t.Errorf("Unexpected numberOfEmployees: %v", document["numberOfEmployees"])
+	}
+	parent, _ := document["parentOrganization"].(map[string]any)
+	if parent["@type"] != "Organization" || parent["name"] != "Acme Holdings" || parent["url"] != "https://acmeholdings.com" || parent["@context"] != nil {
+		t.Errorf("Unexpected parentOrganization: %v", document["parentOrganization"])
+	}
+	// Missing fields are left out
+	for _, key := range []string{"image", "telephone", "alternateName"} {
+		if _, found := document[key]; found {
+			t.Errorf("Expected %s to be omitted, got %v", key, document[key])
+		}
+	}
+
+	encoded, err = Company{}.ToSchemaOrgJSONLD()
+	if err != nil {
+		t.Fatalf("ToSchemaOrgJSONLD returned error: %v", err)
+	}
+	if string(encoded) != `{"@context":"https://schema.org","@type":"Organization"}` {
+		t.Errorf("Expected an empty Organization, got %s", encoded)
+	}
+}
@@ -0,0 +1,122 @@
+package thecompaniesapi
+
+// CreateListOption sets an optional field of the body built by NewListCreate
+type CreateListOption interface {
+	applyCreate(body *CreateListJSONRequestBody)
+}
+
+// UpdateListOption sets a field of the body built by NewListUpdate
+type UpdateListOption interface {
+	applyUpdate(body *UpdateListJSONRequestBody)
+}
+
+// ListOption sets a field shared by the create and update bodies of a list
+type ListOption interface {
+	CreateListOption
+	UpdateListOption
+}
+
+type createListOption func(body *CreateListJSONRequestBody)
+
+func (o createListOption) applyCreate(body *CreateListJSONRequestBody) { o(body) }
+
+type updateListOption func(body *UpdateListJSONRequestBody)
+
+func (o updateListOption) applyUpdate(body *UpdateListJSONRequestBody) { o(body) }
+
+type listOption struct {
+	createListOption
+	updateListOption
+}
+
+// NewListCreate builds the body of CreateList for a list named name
+//
+//	body := thecompaniesapi.NewListCreate("Prospects",
+//		thecompaniesapi.WithListDynamic(true),
+//		thecompaniesapi.WithListQuery(conditions...),
+//	)
+func NewListCreate(name string, opts ...CreateListOption) CreateListJSONRequestBody {
+	body := CreateListJSONRequestBody{Name: name}
+	for _, opt := range opts {
+		opt.applyCreate(&body)
+	}
+	return body
+}
+
+// NewListUpdate builds the body of UpdateList. Fields not set by the options are
+// omitted, except maxCompanies which the generated body always sends (null when unset).
+func NewListUpdate(opts ...UpdateListOption) UpdateListJSONRequestBody {
+	var body UpdateListJSONRequestBody
+	for _, opt := range opts {
+		opt.applyUpdate(&body)
+	}
+	return body
+}
+
+// WithListDynamic sets whether the list is kept in sync with its query
+func WithListDynamic(dynamic bool) ListOption {
+	return listOption{
+		func(body *CreateListJSONRequestBody) { body.Dynamic = &dynamic },
+		func(body *UpdateListJSONRequestBody) { body.Dynamic = &dynamic },
+	}
+}
+
+// WithListMailFrequency sets how often the list digest is emailed (Daily, Weekly,
+// Monthly or Disabled)
+func WithListMailFrequency(frequency UpdateListJSONBodyMailFrequency) ListOption {
+	return listOption{
+		func(body *CreateListJSONRequestBody) {
+			createFrequency := CreateListJSONBodyMailFrequency(frequency)
+			body.MailFrequency = &createFrequency
+		},
+		func(body *UpdateListJSONRequestBody) { body.MailFrequency = &frequency },
+	}
+}
+
+// WithListMaxCompanies caps the number of companies of the list
+func WithListMaxCompanies(maxCompanies int) ListOption {
+	value := float32(maxCompanies)
+	return listOption{
+		func(body *CreateListJSONRequestBody) { body.MaxCompanies = &value },
+		func(body *UpdateListJSONRequestBody) { body.MaxCompanies = &value },
+	}
+}
+
+// WithListQuery sets the segmentation of the companies of the list
+func WithListQuery(conditions ...SegmentationCondition) ListOption {
+	return listOption{
+		func(body *CreateListJSONRequestBody) { body.Query = &conditions },
+		func(body *UpdateListJSONRequestBody) { body.Query = &conditions },
+	}
+}
+
+// WithListImported marks a created list as imported
+func WithListImported(imported bool) CreateListOption {
+	return createListOption(func(body *CreateListJSONRequestBody) { body.Imported = &imported })
+}
+
+// WithListProcessInitialized sets whether the processing of a created list starts
+// right away
+func WithListProcessInitialized(initialized bool) CreateListOption {
+	return createListOption(func(body *CreateListJSONRequestBody) { body.ProcessInitialized = &initialized })
+}
+
+// WithListSimilarDomains fills a created list with companies similar to the domains
+func WithListSimilarDomains(domains ...string) CreateListOption {
+	return createListOption(func(body *CreateListJSONRequestBody) { body.SimilarDomains = &domains })
+}
+
+// WithListName renames the list
+func WithListName(name string) UpdateListOption {
+	return updateListOption(func(body *UpdateListJSONRequestBody) { body.Name = &name })
+}
+
+// WithListLastSeen sets the lastSeen flag of the list
+func WithListLastSeen(lastSeen bool) UpdateListOption {
+	return updateListOption(func(body *UpdateListJSONRequestBody) { body.LastSeen = &lastSeen })
+}
+
+// WithListResync requests the companies of the list to be synchronized again
+func WithListResync(resync bool) UpdateListOption {
+	return updateListOption(func(body *UpdateListJSONRequestBody) { body.Resync = &resync })
+}
@@ -0,0 +1,43 @@
+package thecompaniesapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNewListCreate(t *testing.T) {
+	condition := testCondition(SegmentationConditionAttributeAboutIndustries, And)
+	body := NewListCreate("Prospects",
+		WithListDynamic(true),
+		WithListMailFrequency(Weekly),
+		WithListMaxCompanies(500),
+		WithListQuery(condition),
+		WithListSimilarDomains("apple.com", "stripe.com"),
+	)
+
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("Failed to marshal body: %v", err)
+	}
+	expected := `{"dynamic":true,"mailFrequency":"weekly","maxCompanies":500,"name":"Prospects","query":[{"attribute":"about.industries","operator":"and","sign":"equals","values":null}],"similarDomains":["apple.com","stripe.com"]}`
+	if string(encoded) != expected {
+		t.Errorf("Expected %s, got %s", expected, encoded)
+	}
+
+	if encoded, _ := json.Marshal(NewListCreate("Empty")); string(encoded) != `{"name":"Empty"}` {
+		t.Errorf("Expected only the name to be sent, got %s", encoded)
+	}
+}
+
+func TestNewListUpdate(t *testing.T) {
+	body := NewListUpdate(WithListName("Customers"), WithListDynamic(false), WithListResync(true), WithListMaxCompanies(100))
+
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("Failed to marshal body: %v", err)
+	}
+	expected := `{"dynamic":false,"maxCompanies":100,"name":"Customers","resync":true}`
+	if string(encoded) != expected {
+		t.Errorf("Expected %s, got %s", expected, encoded)
+	}
+}
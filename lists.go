@@ -0,0 +1,264 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// ErrListNotFound is returned when no list has the requested name
+var ErrListNotFound = errors.New("list not found")
+
+// ErrAmbiguousListName is returned when several lists share the requested name
+var ErrAmbiguousListName = errors.New("several lists share this name")
+
+// FindListByName returns the list whose name is exactly name, browsing every page of
+// FetchLists. It returns ErrListNotFound when no list matches and ErrAmbiguousListName
+// when more than one does.
+func (c *CompaniesAPIClient) FindListByName(ctx context.Context, name string) (*List, error) {
+	var matches []List
+	err := c.forEachListsPage(ctx, func(lists []List, meta PaginationMeta) error {
+		for _, list := range lists {
+			if list.Name == name {
+				matches = append(matches, list)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%w: %q", ErrListNotFound, name)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("%w: %d lists are named %q", ErrAmbiguousListName, len(matches), name)
+	}
+}
+
+// DeleteListByName deletes the list named name. The list is resolved with
+// FindListByName first so that nothing is deleted when the name matches no list or
+// several lists, making it a safer alternative to DeleteList with a numeric ID. The
+// lookup may use at most half of the time left before the deadline of ctx (or the
+// default request timeout), keeping the rest for the deletion.
+func (c *CompaniesAPIClient) DeleteListByName(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*DeleteListResponse, error) {
+	budget := c.newCallBudget(ctx, 2)
+	lookupCtx, cancel := budget.next(ctx)
+	list, err := c.FindListByName(lookupCtx, name)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+
+	deleteCtx, cancel := budget.next(ctx)
+	defer cancel()
+	return c.DeleteList(deleteCtx, list.Id, reqEditors...)
+}
+
+// ListsByName returns the IDs of the lists indexed by name, browsing every page of
+// FetchLists. Names must be unique for the map to be unambiguous: when two lists share
+// a name, an error matching ErrAmbiguousListName is returned.
+func (c *CompaniesAPIClient) ListsByName(ctx context.Context) (map[string]float32, error) {
+	ids := make(map[string]float32)
+	err := c.forEachListsPage(ctx, func(lists []List, meta PaginationMeta) error {
+		for _, list := range lists {
+			if id, ok := ids[list.Name]; ok {
+				return fmt.Errorf("%w: lists %v and %v are named %q", ErrAmbiguousListName, id, list.Id, list.Name)
+			}
+			ids[list.Name] = list.Id
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// forEachListsPage fetches the lists page by page and calls fn for every page until
+// the last page is reached, fn returns an error or the context is done
+func (c *CompaniesAPIClient) forEachListsPage(ctx context.Context, fn func(lists []List, meta PaginationMeta) error) error {
+	for page := float32(1); ; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		lists, meta, err := c.fetchListsPage(ctx, nil, page)
+		if err != nil {
+			return err
+		}
+		if err := fn(lists, meta); err != nil {
+			return err
+		}
+		if !hasNextPage(len(lists), page, meta) {
+			return nil
+		}
+	}
+}
+
+// fetchListsPage fetches a page of the lists. params is not modified.
+func (c *CompaniesAPIClient) fetchListsPage(ctx context.Context, params *FetchListsParams, page float32) ([]List, PaginationMeta, error) {
+	pageParams := FetchListsParams{}
+	if params != nil {
+		pageParams = *params
+	}
+	pageParams.Page = &page
+
+	response, err := c.FetchLists(ctx, &pageParams)
+	if err != nil {
+		return nil, PaginationMeta{}, err
+	}
+	if response.StatusCode() != http.StatusOK || response.JSON200 == nil {
+		return nil, PaginationMeta{}, c.baseClient.responseError(response.HTTPResponse, response.Body)
+	}
+	return response.JSON200.Lists, response.JSON200.Meta, nil
+}
+
+// ListsIterator returns a Paginator over the lists, starting at params.Page (or the
+// first page). Short pages are fetched again once, as for SearchCompaniesIterator.
+// params is not modified.
+func (c *CompaniesAPIClient) ListsIterator(ctx context.Context, params *FetchListsParams, options ...PaginatorOption) *Paginator[List] {
+	var start *float32
+	if params != nil {
+		start = params.Page
+	}
+	return newMetaPaginator(ctx, start, func(ctx context.Context, page float32) ([]List, PaginationMeta, error) {
+		return c.fetchListsPage(ctx, params, page)
+	}, options...)
+}
+
+// ListedCompany is a company returned by FetchCompaniesInLists along with the lists
+// it belongs to
+type ListedCompany struct {
+	Company Company
+	// ListIds are the requested lists containing the company, in the requested order
+	ListIds []float32
+}
+
+// FetchCompaniesInLists fetches every page of the companies of several lists
+// concurrently, for cross-list reports, and merges them: a company present in several
+// lists is returned once, with all its lists. Companies are ordered by first
+// appearance, following the order of listIds. params apply to every list, Page
+// excepted as all pages are fetched. The first error cancels the other fetches and is
+// returned.
+func (c *CompaniesAPIClient) FetchCompaniesInLists(ctx context.Context, listIds []float32, params *FetchCompaniesInListParams) ([]ListedCompany, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg        sync.WaitGroup
+		once      sync.Once
+		firstErr  error
+		companies = make([][]Company, len(listIds))
+	)
+	for i, listId := range listIds {
+		wg.Add(1)
+		go func(i int, listId float32) {
+			defer wg.Done()
+			var err error
+			companies[i], err = c.fetchAllCompaniesInList(ctx, listId, params)
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(i, listId)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	index := make(map[string]int)
+	var merged []ListedCompany
+	for i, listCompanies := range companies {
+		for _, company := range listCompanies {
+			domain := strings.ToLower(company.DomainName())
+			position, ok := index[domain]
+			if !ok || domain == "" {
+				position = len(merged)
+				index[domain] = position
+				merged = append(merged, ListedCompany{Company: company})
+			}
+			if ids := merged[position].ListIds; len(ids) == 0 || ids[len(ids)-1] != listIds[i] {
+				merged[position].ListIds = append(ids, listIds[i])
+			}
+		}
+	}
+	return merged, nil
+}
+
+// fetchAllCompaniesInList fetches every page of the companies of a list
+func (c *CompaniesAPIClient) fetchAllCompaniesInList(ctx context.Context, listId float32, params *FetchCompaniesInListParams) ([]Company, error) {
+	var companies []Company
+	err := c.forEachCompaniesInListPage(ctx, listId, params, func(page []Company, meta PaginationMeta) error {
+		companies = append(companies, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return companies, nil
+}
+
+// forEachCompaniesInListPage fetches the companies of a list page by page, starting at
+// the first page, and calls fn for every page until the last page is reached, fn
+// returns an error or the context is done. params is not modified.
+func (c *CompaniesAPIClient) forEachCompaniesInListPage(ctx context.Context, listId float32, params *FetchCompaniesInListParams, fn func(companies []Company, meta PaginationMeta) error) error {
+	for page := float32(1); ; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		companies, meta, err := c.fetchCompaniesInListPage(ctx, listId, params, page)
+		if err != nil {
+			return err
+		}
+		if err := fn(companies, meta); err != nil {
+			return err
+		}
+		if !hasNextPage(len(companies), page, meta) {
+			return nil
+		}
+	}
+}
+
+// fetchCompaniesInListPage fetches a page of the companies of a list. params is not
+// modified.
+func (c *CompaniesAPIClient) fetchCompaniesInListPage(ctx context.Context, listId float32, params *FetchCompaniesInListParams, page float32) ([]Company, PaginationMeta, error) {
+	pageParams := FetchCompaniesInListParams{}
+	if params != nil {
+		pageParams = *params
+	}
+	pageParams.Page = &page
+
+	response, err := c.FetchCompaniesInList(ctx, listId, &pageParams)
+	if err != nil {
+		return nil, PaginationMeta{}, err
+	}
+	if response.StatusCode() != http.StatusOK || response.JSON200 == nil {
+		return nil, PaginationMeta{}, c.baseClient.responseError(response.HTTPResponse, response.Body)
+	}
+	return response.JSON200.Companies, response.JSON200.Meta, nil
+}
+
+// CompaniesInListIterator returns a Paginator over the companies of a list, starting
+// at params.Page (or the first page). Short pages are fetched again once, as for
+// SearchCompaniesIterator. params is not modified.
+func (c *CompaniesAPIClient) CompaniesInListIterator(ctx context.Context, listId float32, params *FetchCompaniesInListParams, options ...PaginatorOption) *Paginator[Company] {
+	var start *float32
+	if params != nil {
+		start = params.Page
+	}
+	return newMetaPaginator(ctx, start, func(ctx context.Context, page float32) ([]Company, PaginationMeta, error) {
+		return c.fetchCompaniesInListPage(ctx, listId, params, page)
+	}, options...)
+}
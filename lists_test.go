@@ -0,0 +1,142 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDeleteListByName(t *testing.T) {
+	pages := map[string]string{
+		"1": `{"lists":[{"id":1,"name":"Prospects"},{"id":2,"name":"Customers"}],"meta":{"currentPage":1,"lastPage":2}}`,
+		"2": `{"lists":[{"id":3,"name":"Customers"},{"id":4,"name":"Partners"}],"meta":{"currentPage":2,"lastPage":2}}`,
+	}
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/lists":
+			w.Write([]byte(pages[r.URL.Query().Get("page")]))
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+			w.Write([]byte(`{"id":4,"name":"Partners"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+	ctx := context.Background()
+
+	// The unique match is on the second page
+	if _, err := client.DeleteListByName(ctx, "Partners"); err != nil {
+		t.Fatalf("DeleteListByName returned error: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0] != "/v2/lists/4" {
+		t.Errorf("Expected list 4 to be deleted, got %v", deleted)
+	}
+
+	deleted = nil
+	if _, err := client.DeleteListByName(ctx, "Leads"); !errors.Is(err, ErrListNotFound) {
+		t.Errorf("Expected ErrListNotFound, got %v", err)
+	}
+	if _, err := client.DeleteListByName(ctx, "customers"); !errors.Is(err, ErrListNotFound) {
+		t.Errorf("Expected names to match exactly, got %v", err)
+	}
+	if _, err := client.DeleteListByName(ctx, "Customers"); !errors.Is(err, ErrAmbiguousListName) {
+		t.Errorf("Expected ErrAmbiguousListName, got %v", err)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("Expected no deletion without a unique match, got %v", deleted)
+	}
+}
+
+func TestFetchCompaniesInLists(t *testing.T) {
+	responses := map[string]string{
+		"/v2/lists/1/companies?page=1": `{"companies":[{"domain":{"domain":"apple.com"}},{"domain":{"domain":"stripe.com"}}],"meta":{"currentPage":1,"lastPage":2}}`,
+		"/v2/lists/1/companies?page=2": `{"companies":[{"domain":{"domain":"figma.com"}}],"meta":{"currentPage":2,"lastPage":2}}`,
+		"/v2/lists/2/companies?page=1": `{"companies":[{"domain":{"domain":"Stripe.com"}},{"domain":{"domain":"notion.so"}}],"meta":{"currentPage":1,"lastPage":1}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		response, ok := responses[r.URL.Path+"?page="+r.URL.Query().Get("page")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":"not_found","message":"List not found"}`))
+			return
+		}
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	companies, err := client.FetchCompaniesInLists(context.Background(), []float32{1, 2}, nil)
+	if err != nil {
+		t.Fatalf("FetchCompaniesInLists returned error: %v", err)
+	}
+
+	expected := []struct {
+		domain  string
+		listIds []float32
+	}{
+		{"apple.com", []float32{1}},
+		{"stripe.com", []float32{1, 2}},
+		{"figma.com", []float32{1}},
+		{"notion.so", []float32{2}},
+	}
+	if len(companies) != len(expected) {
+		t.Fatalf("Expected %d companies, got %d", len(expected), len(companies))
+	}
+	for i, e := range expected {
+		company := companies[i]
+		if company.Company.DomainName() != e.domain || fmt.Sprint(company.ListIds) != fmt.Sprint(e.listIds) {
+			t.Errorf("Expected %s in lists %v, got %s in %v", e.domain, e.listIds, company.Company.DomainName(), company.ListIds)
+		}
+	}
+
+	// A failing list fails the whole fetch
+	if _, err := client.FetchCompaniesInLists(context.Background(), []float32{1, 3}, nil); err == nil || err.Error() != "not_found: List not found" {
+		t.Errorf("Expected the missing list error, got %v", err)
+	}
+}
+
+func TestListsByName(t *testing.T) {
+	pages := map[string]string{
+		"1": `{"lists":[{"id":1,"name":"Prospects"},{"id":2,"name":"Customers"}],"meta":{"currentPage":1,"lastPage":2}}`,
+		"2": `{"lists":[{"id":4,"name":"Partners"}],"meta":{"currentPage":2,"lastPage":2}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(pages[r.URL.Query().Get("page")]))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	ids, err := client.ListsByName(context.Background())
+	if err != nil {
+		t.Fatalf("ListsByName returned error: %v", err)
+	}
+	if len(ids) != 3 || ids["Prospects"] != 1 || ids["Customers"] != 2 || ids["Partners"] != 4 {
+		t.Errorf("Unexpected lists: %v", ids)
+	}
+
+	pages["2"] = `{"lists":[{"id":3,"name":"Customers"}],"meta":{"currentPage":2,"lastPage":2}}`
+	if _, err := client.ListsByName(context.Background()); !errors.Is(err, ErrAmbiguousListName) {
+		t.Errorf("Expected ErrAmbiguousListName, got %v", err)
+	}
+}
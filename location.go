@@ -0,0 +1,61 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// NameSearchLocation scopes SearchCompaniesByNameInLocation to the countries and
+// cities returned by SearchCountries and SearchCities
+type NameSearchLocation struct {
+	Countries []NominatimCountry
+	Cities    []NominatimCity
+}
+
+// SearchCompaniesByNameInLocation searches companies by name among the companies
+// headquartered in location, company names not being unique across regions. The
+// country codes are sent in the comma-separated countries parameter of
+// SearchCompaniesByName, merged with the countries already set in params. The endpoint
+// has no city filter: the companies of the page are filtered by headquarters city
+// code, so that the result may hold fewer companies than the requested size.
+func (c *CompaniesAPIClient) SearchCompaniesByNameInLocation(ctx context.Context, params *SearchCompaniesByNameParams, location NameSearchLocation, reqEditors ...RequestEditorFn) ([]Company, error) {
+	scoped := SearchCompaniesByNameParams{}
+	if params != nil {
+		scoped = *params
+	}
+	if len(location.Countries) > 0 {
+		var codes []string
+		if scoped.Countries != nil && *scoped.Countries != "" {
+			codes = strings.Split(*scoped.Countries, ",")
+		}
+		for _, country := range location.Countries {
+			codes = append(codes, country.Code)
+		}
+		countries := strings.Join(codes, ",")
+		scoped.Countries = &countries
+	}
+
+	response, err := c.SearchCompaniesByName(ctx, &scoped, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() != http.StatusOK || response.JSON200 == nil {
+		return nil, c.baseClient.responseError(response.HTTPResponse, response.Body)
+	}
+	if len(location.Cities) == 0 {
+		return response.JSON200.Companies, nil
+	}
+
+	cities := make(map[string]bool, len(location.Cities))
+	for _, city := range location.Cities {
+		cities[strings.ToLower(city.Code)] = true
+	}
+	var companies []Company
+	for _, company := range response.JSON200.Companies {
+		if cities[strings.ToLower(company.CityCode())] {
+			companies = append(companies, company)
+		}
+	}
+	return companies, nil
+}
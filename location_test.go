@@ -0,0 +1,60 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestSearchCompaniesByNameInLocation(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"companies":[
+			{"domain":{"domain":"apple.com"},"locations":{"headquarters":{"city":{"code":"cupertino"},"country":{"code":"us"}}}},
+			{"domain":{"domain":"apple.fr"},"locations":{"headquarters":{"city":{"code":"paris"},"country":{"code":"fr"}}}},
+			{"domain":{"domain":"apple.de"}}
+		],"meta":{}}`))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	countries := "de"
+	companies, err := client.SearchCompaniesByNameInLocation(context.Background(),
+		&SearchCompaniesByNameParams{Name: "Apple", Countries: &countries},
+		NameSearchLocation{Countries: []NominatimCountry{{Code: "us"}, {Code: "fr"}}},
+	)
+	if err != nil {
+		t.Fatalf("SearchCompaniesByNameInLocation returned error: %v", err)
+	}
+	if query.Get("name") != "Apple" || query.Get("countries") != "de,us,fr" {
+		t.Errorf("Expected the countries to be sent, got %v", query)
+	}
+	if countries != "de" {
+		t.Errorf("Expected the params to be left untouched, got %q", countries)
+	}
+	if len(companies) != 3 {
+		t.Errorf("Expected every company without city filter, got %d", len(companies))
+	}
+
+	companies, err = client.SearchCompaniesByNameInLocation(context.Background(),
+		&SearchCompaniesByNameParams{Name: "Apple"},
+		NameSearchLocation{Cities: []NominatimCity{{Code: "Paris"}}},
+	)
+	if err != nil {
+		t.Fatalf("SearchCompaniesByNameInLocation returned error: %v", err)
+	}
+	if query.Has("countries") {
+		t.Errorf("Expected no countries without country filter, got %v", query)
+	}
+	if len(companies) != 1 || companies[0].DomainName() != "apple.fr" {
+		t.Errorf("Expected the companies of the city, got %v", companies)
+	}
+}
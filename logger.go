@@ -0,0 +1,97 @@
+package thecompaniesapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// WithLogger logs every request sent by the client with its operation, method, URL,
+// status and duration. Successful requests are logged at the Info level, unless
+// sampled out with WithLogSampling, or at the Warn level when slower than the threshold
+// of WithSlowRequestThreshold; transport errors and unsuccessful responses are always
+// logged at the Error level. WithJSONIndent adds the request bodies to the logs.
+func WithLogger(logger *slog.Logger) BaseClientOption {
+	return func(c *BaseClient) {
+		c.middlewares = append(c.middlewares, func(next roundTripFunc) roundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				body, logBody := c.loggedRequestBody(req)
+				start := time.Now()
+				resp, err := next(req)
+				duration := time.Since(start)
+
+				attrs := []slog.Attr{
+					slog.String("operation", requestOperationName(req)),
+					slog.String("method", req.Method),
+					slog.String("url", req.URL.Redacted()),
+					slog.Duration("duration", duration),
+				}
+				if logBody {
+					attrs = append(attrs, slog.String("body", body))
+				}
+				switch {
+				case err != nil:
+					attrs = append(attrs, slog.String("error", err.Error()))
+					logger.LogAttrs(req.Context(), slog.LevelError, "request failed", attrs...)
+				case resp.StatusCode >= 400:
+					attrs = append(attrs, slog.Int("status", resp.StatusCode))
+					logger.LogAttrs(req.Context(), slog.LevelError, "request failed", attrs...)
+				case c.isSlowRequest(duration):
+					attrs = append(attrs, slog.Int("status", resp.StatusCode), slog.Duration("threshold", c.slowThreshold))
+					logger.LogAttrs(req.Context(), slog.LevelWarn, "slow request", attrs...)
+				case c.sampleLog():
+					attrs = append(attrs, slog.Int("status", resp.StatusCode))
+					logger.LogAttrs(req.Context(), slog.LevelInfo, "request completed", attrs...)
+				}
+				return resp, err
+			}
+		})
+	}
+}
+
+// WithLogSampling limits the successful requests logged by WithLogger to a random
+// fraction rate between 0 and 1, keeping log volume manageable in production. Failed
+// requests are always logged.
+func WithLogSampling(rate float64) BaseClientOption {
+	return func(c *BaseClient) {
+		c.logSampling = &rate
+	}
+}
+
+// sampleLog reports whether a successful request should be logged
+func (c *BaseClient) sampleLog() bool {
+	if c.logSampling == nil {
+		return true
+	}
+	return rand.Float64() < *c.logSampling
+}
+
+// WithJSONIndent adds the JSON body of the requests to the logs of WithLogger,
+// pretty-printed with indent (e.g. "  ") to make them readable while debugging. The
+// body sent to the API stays compact. Bodies streamed with WithStreamingRequestBody
+// are not logged since reading them would buffer them.
+func WithJSONIndent(indent string) BaseClientOption {
+	return func(c *BaseClient) {
+		c.logJSONIndent = &indent
+	}
+}
+
+// loggedRequestBody returns the indented JSON body of a request, reporting false when
+// it is not to be logged
+func (c *BaseClient) loggedRequestBody(req *http.Request) (string, bool) {
+	if c.logJSONIndent == nil || req.GetBody == nil {
+		return "", false
+	}
+	body, err := readRequestBody(req)
+	if err != nil || len(body) == 0 {
+		return "", false
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, body, "", *c.logJSONIndent); err != nil {
+		return "", false
+	}
+	return indented.String(), true
+}
@@ -0,0 +1,121 @@
+package thecompaniesapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// stubTransport answers every request with the status of the request path
+type stubTransport struct{}
+
+func (stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path == "/unreachable" {
+		return nil, errors.New("connection refused")
+	}
+	status := http.StatusOK
+	if req.URL.Path == "/missing" {
+		status = http.StatusNotFound
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestWithLogger(t *testing.T) {
+	var logs bytes.Buffer
+	client := NewBaseClient("test-api-key",
+		WithCustomHTTPClient(&http.Client{Transport: stubTransport{}}),
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+	)
+
+	client.MakeRequest(context.Background(), "GET", "/v2/companies/apple.com", nil)
+	if line := logs.String(); !strings.Contains(line, "request completed") || !strings.Contains(line, "operation=FetchCompany") || !strings.Contains(line, "status=200") {
+		t.Errorf("Unexpected log: %s", line)
+	}
+}
+
+func TestWithLogSampling(t *testing.T) {
+	var logs bytes.Buffer
+	client := NewBaseClient("test-api-key",
+		WithCustomHTTPClient(&http.Client{Transport: stubTransport{}}),
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+		WithLogSampling(0.2),
+	)
+	ctx := context.Background()
+
+	const calls = 2000
+	for i := 0; i < calls; i++ {
+		client.MakeRequest(ctx, "GET", "/v2/health", nil)
+	}
+	if logged := strings.Count(logs.String(), "request completed"); logged < calls/10 || logged > calls*3/10 {
+		t.Errorf("Expected about 20%% of %d requests to be logged, got %d", calls, logged)
+	}
+
+	logs.Reset()
+	for i := 0; i < 50; i++ {
+		client.MakeRequest(ctx, "GET", "/missing", nil)
+		client.MakeRequest(ctx, "GET", "/unreachable", nil)
+	}
+	if failed := strings.Count(logs.String(), "request failed"); failed != 100 {
+		t.Errorf("Expected every failed request to be logged, got %d", failed)
+	}
+	if !strings.Contains(logs.String(), "status=404") || !strings.Contains(logs.String(), "connection refused") {
+		t.Errorf("Expected failure details in the logs: %s", logs.String())
+	}
+}
+
+// recordingTransport records the bodies of the requests it answers with stubTransport
+type recordingTransport struct {
+	bodies []string
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, _ := io.ReadAll(req.Body)
+	t.bodies = append(t.bodies, string(body))
+	return stubTransport{}.RoundTrip(req)
+}
+
+func TestWithJSONIndent(t *testing.T) {
+	var logs bytes.Buffer
+	transport := &recordingTransport{}
+	client := NewBaseClient("test-api-key",
+		WithCustomHTTPClient(&http.Client{Transport: transport}),
+		WithLogger(slog.New(slog.NewJSONHandler(&logs, nil))),
+		WithJSONIndent("  "),
+	)
+
+	client.MakeRequest(context.Background(), "POST", "/v2/companies", map[string]any{"query": []string{"saas"}})
+	var entry struct {
+		Body string `json:"body"`
+	}
+	if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to decode log %s: %v", logs.String(), err)
+	}
+	if expected := "{\n  \"query\": [\n    \"saas\"\n  ]\n}"; entry.Body != expected {
+		t.Errorf("Expected the logged body to be indented, got %q", entry.Body)
+	}
+	if len(transport.bodies) != 1 || transport.bodies[0] != `{"query":["saas"]}` {
+		t.Errorf("Expected the sent body to be compact, got %q", transport.bodies)
+	}
+
+	// Bodies are only logged on demand
+	logs.Reset()
+	client = NewBaseClient("test-api-key",
+		WithCustomHTTPClient(&http.Client{Transport: transport}),
+		WithLogger(slog.New(slog.NewJSONHandler(&logs, nil))),
+	)
+	client.MakeRequest(context.Background(), "POST", "/v2/companies", map[string]any{"query": []string{"saas"}})
+	if strings.Contains(logs.String(), `"body"`) {
+		t.Errorf("Expected no body in the logs: %s", logs.String())
+	}
+}
@@ -0,0 +1,45 @@
+package thecompaniesapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultMaintenanceRetryDelay is the delay before retrying a request answered by the
+// maintenance page, unless WithMaintenanceBackoff is used
+const DefaultMaintenanceRetryDelay = 30 * time.Second
+
+// ErrServiceUnavailable is matched by the errors of requests answered with a 503 while
+// the API is under maintenance, as opposed to other server errors. The error also
+// unwraps to the *Error or *HTTPError built from the response.
+var ErrServiceUnavailable = errors.New("service unavailable for maintenance")
+
+// WithMaintenanceBackoff sets the strategy computing the delay before retrying a
+// request answered by the maintenance page, a ConstantBackoff of
+// DefaultMaintenanceRetryDelay by default. It only applies when WithRetry is enabled.
+func WithMaintenanceBackoff(strategy BackoffStrategy) BaseClientOption {
+	return func(c *BaseClient) {
+		c.retryPolicy().maintenanceBackoff = strategy
+	}
+}
+
+// isMaintenanceResponse reports whether a response is the maintenance page of the API:
+// a 503 whose JSON error code is "maintenance" or "service_unavailable", or whose body
+// mentions the maintenance
+func isMaintenanceResponse(statusCode int, body []byte) bool {
+	if statusCode != http.StatusServiceUnavailable {
+		return false
+	}
+	var apiErr Error
+	if json.Unmarshal(body, &apiErr) == nil {
+		switch strings.ToLower(apiErr.Code) {
+		case "maintenance", "service_unavailable":
+			return true
+		}
+	}
+	return bytes.Contains(bytes.ToLower(body), []byte("maintenance"))
+}
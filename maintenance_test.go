@@ -0,0 +1,65 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMaintenanceResponse(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"code":"maintenance","message":"The API is under maintenance"}`))
+	}))
+	defer server.Close()
+
+	backoff := &recordingBackoff{}
+	maintenanceBackoff := &recordingBackoff{}
+	client := NewBaseClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithRetry(3),
+		WithBackoffStrategy(backoff),
+		WithMaintenanceBackoff(maintenanceBackoff),
+	)
+
+	_, err := client.MakeRequest(context.Background(), "GET", "/v2/companies/apple.com", nil)
+	if !errors.Is(err, ErrServiceUnavailable) {
+		t.Fatalf("Expected ErrServiceUnavailable, got %v", err)
+	}
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected the API error to be wrapped, got %v", err)
+	}
+
+	// Maintenance responses are retried with the maintenance backoff
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+	if len(maintenanceBackoff.attempts) != 2 || len(backoff.attempts) != 0 {
+		t.Errorf("Expected the maintenance backoff for both retries, got %v and %v", maintenanceBackoff.attempts, backoff.attempts)
+	}
+	if NewBaseClient("test-api-key").retryPolicy().maintenanceBackoff != (ConstantBackoff{Delay: DefaultMaintenanceRetryDelay}) {
+		t.Error("Expected the default maintenance backoff")
+	}
+}
+
+func TestGenericServiceUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"code":"overloaded","message":"Try again later"}`))
+	}))
+	defer server.Close()
+
+	client := NewBaseClient("test-api-key", WithCustomBaseURL(server.URL))
+	_, err := client.MakeRequest(context.Background(), "GET", "/v2/companies/apple.com", nil)
+	var apiErr *Error
+	if errors.Is(err, ErrServiceUnavailable) || !errors.As(err, &apiErr) {
+		t.Errorf("Expected a plain API error, got %v", err)
+	}
+}
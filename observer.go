@@ -0,0 +1,90 @@
+package thecompaniesapi
+
+import (
+	"net/http"
+	"net/http/httptrace"
+	"sync/atomic"
+	"time"
+)
+
+// Observer receives events about the requests of a client, e.g. to feed metrics. Like
+// httptrace.ClientTrace, every hook is optional and may be called concurrently from
+// several requests; hooks must not block.
+type Observer struct {
+	// ConnectionObtained is called every time a request attempt obtains a connection,
+	// reporting whether it was reused from the pool
+	ConnectionObtained func(event ConnectionEvent)
+	// CreditsUsed is called for every response reporting the credits it consumed in
+	// the meta.cost field of its body
+	CreditsUsed func(event CreditsEvent)
+	// SlowRequest is called for every request attempt lasting longer than the
+	// threshold set by WithSlowRequestThreshold
+	SlowRequest func(event SlowRequestEvent)
+}
+
+// ConnectionEvent describes the connection obtained by a request attempt
+type ConnectionEvent struct {
+	// Operation is the API operation of the request, e.g. "FetchCompany"
+	Operation string
+	// Reused reports whether the connection was previously used for another request
+	Reused bool
+	// WasIdle reports whether the connection was obtained from the idle pool
+	WasIdle bool
+	// IdleTime is how long the connection was idle, when WasIdle is true
+	IdleTime time.Duration
+}
+
+// WithObserver registers the hooks of observer for every request. The hooks are
+// combined with the trace set by WithClientTrace.
+func WithObserver(observer Observer) BaseClientOption {
+	return func(c *BaseClient) {
+		c.observer = &observer
+	}
+}
+
+// observeConnection attaches the connection hooks of the observer to the request
+func (c *BaseClient) observeConnection(req *http.Request) *http.Request {
+	if c.observer == nil || c.observer.ConnectionObtained == nil {
+		return req
+	}
+	observe := c.observer.ConnectionObtained
+	operation := requestOperationName(req)
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			observe(ConnectionEvent{
+				Operation: operation,
+				Reused:    info.Reused,
+				WasIdle:   info.WasIdle,
+				IdleTime:  info.IdleTime,
+			})
+		},
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+}
+
+// ConnectionCounter counts new and reused connections, to diagnose connection churn
+// and tune the connection pool. Register it with
+// WithObserver(Observer{ConnectionObtained: counter.Observe}).
+type ConnectionCounter struct {
+	created atomic.Int64
+	reused  atomic.Int64
+}
+
+// Observe records a connection event
+func (c *ConnectionCounter) Observe(event ConnectionEvent) {
+	if event.Reused {
+		c.reused.Add(1)
+	} else {
+		c.created.Add(1)
+	}
+}
+
+// New returns the number of new connections opened
+func (c *ConnectionCounter) New() int64 {
+	return c.created.Load()
+}
+
+// Reused returns the number of connections reused from the pool
+func (c *ConnectionCounter) Reused() int64 {
+	return c.reused.Load()
+}
@@ -0,0 +1,54 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestObserverConnectionReuse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var (
+		mu     sync.Mutex
+		events []ConnectionEvent
+	)
+	counter := &ConnectionCounter{}
+	client := NewBaseClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithObserver(Observer{ConnectionObtained: func(event ConnectionEvent) {
+			mu.Lock()
+			events = append(events, event)
+			mu.Unlock()
+			counter.Observe(event)
+		}}),
+	)
+
+	// The second request reuses the connection kept alive by the first one
+	for i := 0; i < 2; i++ {
+		if _, err := client.MakeRequest(context.Background(), "GET", "/v2/companies/apple.com", nil); err != nil {
+			t.Fatalf("MakeRequest failed: %v", err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 connection events, got %d", len(events))
+	}
+	if events[0].Reused || !events[1].Reused || !events[1].WasIdle {
+		t.Errorf("Expected a new then a reused connection, got %+v", events)
+	}
+	if events[0].Operation != "FetchCompany" {
+		t.Errorf("Expected the operation name, got %q", events[0].Operation)
+	}
+	if counter.New() != 1 || counter.Reused() != 1 {
+		t.Errorf("Expected 1 new and 1 reused connection, got %d and %d", counter.New(), counter.Reused())
+	}
+}
@@ -0,0 +1,132 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// operationRoute maps a path template of the OpenAPI specification to its operation
+type operationRoute struct {
+	method    string
+	segments  []string
+	name      string
+	operation *openapi3.Operation
+}
+
+var (
+	operationRoutesOnce sync.Once
+	operationRoutes     []operationRoute
+)
+
+// loadOperationRoutes decodes the embedded OpenAPI specification once and indexes its operations
+func loadOperationRoutes() []operationRoute {
+	operationRoutesOnce.Do(func() {
+		swagger, err := embeddedSpec()
+		if err != nil {
+			return
+		}
+		for path, item := range swagger.Paths.Map() {
+			for method, operation := range item.Operations() {
+				operationRoutes = append(operationRoutes, operationRoute{
+					method:    method,
+					segments:  strings.Split(strings.Trim(path, "/"), "/"),
+					name:      operation.OperationID,
+					operation: operation,
+				})
+			}
+		}
+	})
+	return operationRoutes
+}
+
+// operationName resolves the name of the API operation (e.g. "FetchCompany") served by the
+// given method and path. Literal path segments take precedence over parameters so that
+// "/v2/companies/count" resolves to CountCompanies rather than FetchCompany. Requests that
+// do not match any known operation are named after their method and path.
+func operationName(method, path string) string {
+	route := matchOperationRoute(method, path)
+	if route == nil {
+		return method + " " + path
+	}
+	return route.name
+}
+
+// matchOperationRoute returns the operation route serving the given method and path,
+// nil when none does
+func matchOperationRoute(method, path string) *operationRoute {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	routes := loadOperationRoutes()
+
+	var match *operationRoute
+	bestScore := -1
+	for i, route := range routes {
+		if route.method != method || len(route.segments) != len(segments) {
+			continue
+		}
+		score := 0
+		for j, segment := range route.segments {
+			if strings.HasPrefix(segment, "{") {
+				continue
+			}
+			if segment != segments[j] {
+				score = -1
+				break
+			}
+			score++
+		}
+		if score > bestScore {
+			match, bestScore = &routes[i], score
+		}
+	}
+	return match
+}
+
+// requestOperationName resolves the operation name of an outgoing request, its method
+// and path when it matches no known operation
+func requestOperationName(req *http.Request) string {
+	if name, ok := requestOperation(req); ok {
+		return name
+	}
+	return req.Method + " " + req.URL.Path
+}
+
+// requestOperation resolves the operation name of an outgoing request, reporting false
+// when it matches no known operation. The path of the base URL is stripped first.
+func requestOperation(req *http.Request) (string, bool) {
+	path := req.URL.Path
+	if basePath, ok := req.Context().Value(basePathKey{}).(string); ok {
+		path = strings.TrimPrefix(path, basePath)
+	}
+	route := matchOperationRoute(req.Method, path)
+	if route == nil {
+		return "", false
+	}
+	return route.name, true
+}
+
+// basePathKey carries the path of the base URL of the client sending a request
+type basePathKey struct{}
+
+// withBasePath records the path of the base URL on the request, when it has one, so
+// that its operation is resolved on the path relative to the base URL
+func (c *BaseClient) withBasePath(req *http.Request) *http.Request {
+	if c.basePath == "" {
+		return req
+	}
+	return req.WithContext(context.WithValue(req.Context(), basePathKey{}, c.basePath))
+}
+
+// baseURLPath returns the path of a base URL without trailing slash, e.g. "/tca" for
+// "https://proxy/tca/"
+func baseURLPath(baseURL string) string {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimRight(parsed.Path, "/")
+}
@@ -0,0 +1,42 @@
+package thecompaniesapi
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOperationName(t *testing.T) {
+	tests := []struct {
+		method   string
+		path     string
+		expected string
+	}{
+		{"GET", "/", "FetchApiHealth"},
+		{"GET", "/v2/companies/count", "CountCompanies"},
+		{"POST", "/v2/companies/count", "CountCompaniesPost"},
+		{"GET", "/v2/companies/example.com", "FetchCompany"},
+		{"GET", "/v2/companies/example.com/context", "FetchCompanyContext"},
+		{"PATCH", "/v2/lists/42", "UpdateList"},
+		{"GET", "/v2/unknown", "GET /v2/unknown"},
+	}
+
+	for _, tt := range tests {
+		if name := operationName(tt.method, tt.path); name != tt.expected {
+			t.Errorf("operationName(%s, %s) = %s, expected %s", tt.method, tt.path, name, tt.expected)
+		}
+	}
+}
+
+func TestRequestOperationNameBasePath(t *testing.T) {
+	client := NewBaseClient("test-api-key", WithCustomBaseURL("https://proxy.example.com/tca/"))
+	for path, expected := range map[string]string{
+		"/tca/v2/companies/example.com": "FetchCompany",
+		"/tca/":                         "FetchApiHealth",
+		"/tca/v2/unknown":               "GET /tca/v2/unknown",
+	} {
+		req := client.withBasePath(httptest.NewRequest("GET", "https://proxy.example.com"+path, nil))
+		if name := requestOperationName(req); name != expected {
+			t.Errorf("requestOperationName(%s) = %s, expected %s", path, name, expected)
+		}
+	}
+}
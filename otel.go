@@ -0,0 +1,59 @@
+//go:build otel
+
+package thecompaniesapi
+
+import (
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope reported on the spans created by the SDK
+const tracerName = "github.com/thecompaniesapi/sdk-go"
+
+// WithTracerProvider starts an OpenTelemetry client span around every request, named
+// after the API operation (e.g. "FetchCompany"), or "HTTP " and the method (e.g.
+// "HTTP GET") for requests matching no operation of the API so that span names never
+// carry paths. The span records the HTTP method, URL, path and response status, and
+// is marked as failed on transport errors and responses with a status >= 400.
+//
+// This option is only available when building with the "otel" build tag so that
+// OpenTelemetry stays an optional dependency.
+func WithTracerProvider(provider trace.TracerProvider) BaseClientOption {
+	return func(c *BaseClient) {
+		tracer := provider.Tracer(tracerName)
+		c.middlewares = append(c.middlewares, func(next roundTripFunc) roundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				name, ok := requestOperation(req)
+				if !ok {
+					name = "HTTP " + req.Method
+				}
+				ctx, span := tracer.Start(req.Context(), name,
+					trace.WithSpanKind(trace.SpanKindClient),
+					trace.WithAttributes(
+						attribute.String("http.request.method", req.Method),
+						attribute.String("url.full", req.URL.String()),
+						attribute.String("url.path", req.URL.Path),
+					),
+				)
+				defer span.End()
+
+				resp, err := next(req.WithContext(ctx))
+				if err != nil {
+					span.RecordError(err)
+					span.SetStatus(codes.Error, err.Error())
+					return resp, err
+				}
+
+				span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+				if resp.StatusCode >= 400 {
+					span.SetStatus(codes.Error, "HTTP "+strconv.Itoa(resp.StatusCode))
+				}
+				return resp, nil
+			}
+		})
+	}
+}
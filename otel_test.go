@@ -0,0 +1,92 @@
+//go:build otel
+
+package thecompaniesapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWithTracerProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/tca/v2/companies/unknown.com" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"count":42}`))
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	client, err := ApiClient("test-api-key",
+		WithCustomBaseURL(server.URL+"/tca"),
+		WithTracerProvider(provider),
+	)
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := client.CountCompanies(ctx, &CountCompaniesParams{}); err != nil {
+		t.Fatalf("CountCompanies failed: %v", err)
+	}
+	if _, err := client.FetchCompany(ctx, "unknown.com", nil); err != nil {
+		t.Fatalf("FetchCompany failed: %v", err)
+	}
+	if _, err := client.baseClient.MakeRequest(ctx, "GET", "/v2/unknown/apple.com", nil); err != nil {
+		t.Fatalf("MakeRequest failed: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Expected 3 spans, got %d", len(spans))
+	}
+
+	expected := []struct {
+		name   string
+		status int64
+		code   codes.Code
+	}{
+		{"CountCompanies", http.StatusOK, codes.Unset},
+		{"FetchCompany", http.StatusNotFound, codes.Error},
+		{"HTTP GET", http.StatusOK, codes.Unset},
+	}
+	for i, span := range spans {
+		if span.Name() != expected[i].name {
+			t.Errorf("Expected span name %s, got %s", expected[i].name, span.Name())
+		}
+		if span.Status().Code != expected[i].code {
+			t.Errorf("Expected span status %v, got %v", expected[i].code, span.Status().Code)
+		}
+
+		var (
+			status int64
+			path   string
+		)
+		for _, attr := range span.Attributes() {
+			switch attr.Key {
+			case attribute.Key("http.response.status_code"):
+				status = attr.Value.AsInt64()
+			case attribute.Key("url.path"):
+				path = attr.Value.AsString()
+			}
+		}
+		if !strings.HasPrefix(path, "/tca/v2/") {
+			t.Errorf("Expected the path attribute on %s, got %q", span.Name(), path)
+		}
+		if status != expected[i].status {
+			t.Errorf("Expected status attribute %d on %s, got %d", expected[i].status, span.Name(), status)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"net/http"
+)
+
+// forEachSearchCompaniesPage fetches the search results page by page, starting at
+// params.Page (or the first page), and calls fn for every page until the last page is
+// reached, fn returns an error or the context is done. params is not modified.
+func (c *CompaniesAPIClient) forEachSearchCompaniesPage(ctx context.Context, params *SearchCompaniesParams, fn func(companies []CompanyV2, meta PaginationMeta) error) error {
+	page := float32(1)
+	if params != nil && params.Page != nil {
+		page = *params.Page
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		companies, meta, err := c.fetchSearchCompaniesPage(ctx, params, page)
+		if err != nil {
+			return err
+		}
+		if err := fn(companies, meta); err != nil {
+			return err
+		}
+		if !hasNextPage(len(companies), page, meta) {
+			return nil
+		}
+		page++
+	}
+}
+
+// fetchSearchCompaniesPage fetches a page of the search results. params is not modified.
+func (c *CompaniesAPIClient) fetchSearchCompaniesPage(ctx context.Context, params *SearchCompaniesParams, page float32) ([]Company, PaginationMeta, error) {
+	pageParams := SearchCompaniesParams{}
+	if params != nil {
+		pageParams = *params
+	}
+	pageParams.Page = &page
+
+	response, err := c.SearchCompanies(ctx, &pageParams)
+	if err != nil {
+		return nil, PaginationMeta{}, err
+	}
+	if response.StatusCode() != http.StatusOK || response.JSON200 == nil {
+		return nil, PaginationMeta{}, c.baseClient.responseError(response.HTTPResponse, response.Body)
+	}
+	return response.JSON200.Companies, response.JSON200.Meta, nil
+}
+
+// SearchCompaniesPages runs a search and calls fn with the companies and pagination
+// metadata of every page, fetching the next page only once fn returns. This keeps
+// memory bounded when processing large result sets. Iteration starts at params.Page
+// (or the first page) and stops after the last page, when the context is done or when
+// fn returns an error, which is then returned.
+func (c *CompaniesAPIClient) SearchCompaniesPages(ctx context.Context, params *SearchCompaniesParams, fn func(page []Company, meta PaginationMeta) error) error {
+	return c.forEachSearchCompaniesPage(ctx, params, fn)
+}
+
+// SearchCompaniesIterator returns a Paginator over the companies of a search, starting
+// at params.Page (or the first page). Short pages are fetched again once, as they may
+// be missing results, and WithPagePrefetch fetches the next page in the background.
+// params is not modified.
+func (c *CompaniesAPIClient) SearchCompaniesIterator(ctx context.Context, params *SearchCompaniesParams, options ...PaginatorOption) *Paginator[Company] {
+	var start *float32
+	if params != nil {
+		start = params.Page
+	}
+	return newMetaPaginator(ctx, start, func(ctx context.Context, page float32) ([]Company, PaginationMeta, error) {
+		return c.fetchSearchCompaniesPage(ctx, params, page)
+	}, options...)
+}
@@ -0,0 +1,41 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSearchCompaniesPages(t *testing.T) {
+	server := newPaginatedCompaniesServer(t, 25, 10)
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	var sizes []int
+	err = client.SearchCompaniesPages(context.Background(), nil, func(page []Company, meta PaginationMeta) error {
+		if int(meta.CurrentPage) != len(sizes)+1 {
+			t.Errorf("Expected page %d, got %v", len(sizes)+1, meta.CurrentPage)
+		}
+		sizes = append(sizes, len(page))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SearchCompaniesPages failed: %v", err)
+	}
+	if len(sizes) != 3 || sizes[0] != 10 || sizes[1] != 10 || sizes[2] != 5 {
+		t.Errorf("Expected pages of [10 10 5] companies, got %v", sizes)
+	}
+
+	// The callback error halts the iteration
+	errStop := errors.New("stop")
+	calls := 0
+	err = client.SearchCompaniesPages(context.Background(), nil, func(page []Company, meta PaginationMeta) error {
+		calls++
+		return errStop
+	})
+	if !errors.Is(err, errStop) || calls != 1 {
+		t.Errorf("Expected the iteration to stop after 1 page with errStop, got %d calls and %v", calls, err)
+	}
+}
@@ -0,0 +1,233 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// PageFetcher fetches the items of a page, pages being numbered from 1, and reports
+// whether a next page exists
+type PageFetcher[T any] func(ctx context.Context, page int) (items []T, hasNext bool, err error)
+
+// Paginator iterates over the items of a paginated endpoint, fetching a page only
+// once the items of the previous one have been consumed, unless WithPagePrefetch is
+// set:
+//
+//	companies := client.SearchCompaniesIterator(ctx, params)
+//	defer companies.Close()
+//	for companies.Next() {
+//		company := companies.Value()
+//		// ...
+//	}
+//	if err := companies.Err(); err != nil {
+//		// ...
+//	}
+//
+// The methods of a Paginator are safe for concurrent use. Close in particular may be
+// called from another goroutine to stop an iteration, cancelling the fetch in flight.
+// Goroutines consuming the same Paginator must still serialize their Next and Value
+// calls, since Value returns the item reached by the last Next.
+type Paginator[T any] struct {
+	ctx      context.Context
+	fetch    PageFetcher[T]
+	prefetch bool
+	closed   atomic.Bool
+
+	// cancelMu guards cancelFetch, which cancels the fetch in flight
+	cancelMu    sync.Mutex
+	cancelFetch context.CancelFunc
+
+	// mu guards the iteration state
+	mu      sync.Mutex
+	page    int
+	items   []T
+	index   int
+	hasNext bool
+	err     error
+
+	// pending receives the next page, fetched in the background when prefetching
+	pending chan pageResult[T]
+}
+
+// pageResult is a fetched page
+type pageResult[T any] struct {
+	items   []T
+	hasNext bool
+	err     error
+}
+
+// PaginatorOption configures a Paginator
+type PaginatorOption func(config *paginatorConfig)
+
+type paginatorConfig struct {
+	prefetch bool
+}
+
+// WithPagePrefetch fetches the next page in the background while the caller processes
+// the current one, hiding the latency of the API when processing large result sets.
+// Prefetching stays one page ahead: the next page is fetched by a goroutine ending with
+// the fetch, so an iteration abandoned before its end holds at most one page and one
+// request in flight, and no goroutine once that request completes. A prefetch error is
+// returned by the Next call reaching the failed page, and by Err.
+func WithPagePrefetch() PaginatorOption {
+	return func(config *paginatorConfig) {
+		config.prefetch = true
+	}
+}
+
+// NewPaginator creates a paginator fetching its pages with fetch, starting at page 1
+func NewPaginator[T any](ctx context.Context, fetch PageFetcher[T], options ...PaginatorOption) *Paginator[T] {
+	var config paginatorConfig
+	for _, option := range options {
+		option(&config)
+	}
+	return &Paginator[T]{ctx: ctx, fetch: fetch, prefetch: config.prefetch, index: -1, hasNext: true}
+}
+
+// Next advances to the next item, fetching the next page when the current one is
+// consumed. It returns false after the last item, after Close, when the context is
+// done or when a fetch fails, Err then reporting why.
+func (p *Paginator[T]) Next() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.err != nil || p.closed.Load() {
+		p.items = nil
+		return false
+	}
+	p.index++
+	for p.index >= len(p.items) {
+		if !p.hasNext {
+			return false
+		}
+		result := p.nextPage()
+		if p.closed.Load() {
+			p.items = nil
+			return false
+		}
+		if result.err != nil {
+			p.err = result.err
+			return false
+		}
+		p.items, p.index, p.hasNext = result.items, 0, result.hasNext
+	}
+	return true
+}
+
+// nextPage fetches the next page, or receives it from the background fetch when
+// prefetching, starting the fetch of the page after it
+func (p *Paginator[T]) nextPage() pageResult[T] {
+	p.page++
+	if !p.prefetch {
+		return p.fetchPage(p.page)
+	}
+
+	if p.pending == nil {
+		p.startFetch(p.page)
+	}
+	result := <-p.pending
+	p.pending = nil
+	if result.err == nil && result.hasNext {
+		p.startFetch(p.page + 1)
+	}
+	return result
+}
+
+// startFetch fetches a page in the background, its result being sent to pending
+func (p *Paginator[T]) startFetch(page int) {
+	pending := make(chan pageResult[T], 1)
+	p.pending = pending
+	go func() {
+		pending <- p.fetchPage(page)
+	}()
+}
+
+// fetchPage fetches a page with a context cancelled by Close
+func (p *Paginator[T]) fetchPage(page int) pageResult[T] {
+	ctx, cancel := context.WithCancel(p.ctx)
+	defer cancel()
+	p.cancelMu.Lock()
+	p.cancelFetch = cancel
+	p.cancelMu.Unlock()
+	defer func() {
+		p.cancelMu.Lock()
+		p.cancelFetch = nil
+		p.cancelMu.Unlock()
+	}()
+
+	if p.closed.Load() {
+		cancel()
+	}
+	if err := ctx.Err(); err != nil {
+		return pageResult[T]{err: err}
+	}
+	items, hasNext, err := p.fetch(ctx, page)
+	return pageResult[T]{items: items, hasNext: hasNext, err: err}
+}
+
+// Close stops the iteration, cancelling the fetch in flight, including the background
+// fetch of WithPagePrefetch, after which Next returns false and Err nil. It may be
+// called from any goroutine and more than once.
+func (p *Paginator[T]) Close() {
+	p.closed.Store(true)
+	p.cancelMu.Lock()
+	defer p.cancelMu.Unlock()
+	if p.cancelFetch != nil {
+		p.cancelFetch()
+	}
+}
+
+// Value returns the current item, the zero value before the first call to Next or
+// once Next returned false
+func (p *Paginator[T]) Value() T {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.index < 0 || p.index >= len(p.items) {
+		var zero T
+		return zero
+	}
+	return p.items[p.index]
+}
+
+// Err returns the error that stopped the iteration, nil when it reached the last item
+// or was closed
+func (p *Paginator[T]) Err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+// hasNextPage reports whether a page of count items is followed by another page
+func hasNextPage(count int, page float32, meta PaginationMeta) bool {
+	return count > 0 && page < meta.LastPage
+}
+
+// newMetaPaginator creates a paginator over the pages of an endpoint returning
+// PaginationMeta, starting at page start (or the first page when nil). A short page,
+// holding fewer than PerPage items while Total reports more items after it, hints at a
+// transient backend issue rather than the end of the results: it is fetched again
+// once, the second response being used whatever its length.
+func newMetaPaginator[T any](ctx context.Context, start *float32, fetch func(ctx context.Context, page float32) ([]T, PaginationMeta, error), options ...PaginatorOption) *Paginator[T] {
+	first := float32(1)
+	if start != nil {
+		first = *start
+	}
+	return NewPaginator(ctx, func(ctx context.Context, page int) ([]T, bool, error) {
+		current := first + float32(page-1)
+		items, meta, err := fetch(ctx, current)
+		if err == nil && isShortPage(len(items), current, meta) {
+			items, meta, err = fetch(ctx, current)
+		}
+		return items, hasNextPage(len(items), current, meta), err
+	}, options...)
+}
+
+// isShortPage reports whether a page of count items is missing items, the results not
+// ending with it: its page*perPage is below the total number of results
+func isShortPage(count int, page float32, meta PaginationMeta) bool {
+	if meta.PerPage <= 0 || meta.MaxScrollResultsReached != nil && *meta.MaxScrollResultsReached {
+		return false
+	}
+	return float32(count) < meta.PerPage && page < meta.LastPage && page*meta.PerPage < meta.Total
+}
@@ -0,0 +1,287 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// syntheticPages returns a fetch function serving pages, recording the fetched pages
+func syntheticPages(pages [][]int, fetched *[]int) PageFetcher[int] {
+	return func(ctx context.Context, page int) ([]int, bool, error) {
+		*fetched = append(*fetched, page)
+		return pages[page-1], page < len(pages), nil
+	}
+}
+
+func TestPaginator(t *testing.T) {
+	var fetched []int
+	// Empty pages followed by other pages are skipped
+	paginator := NewPaginator(context.Background(), syntheticPages([][]int{{1, 2}, {}, {3}}, &fetched))
+	if paginator.Value() != 0 {
+		t.Errorf("Expected the zero value before Next, got %d", paginator.Value())
+	}
+
+	var values []int
+	for paginator.Next() {
+		values = append(values, paginator.Value())
+		// Pages are fetched lazily
+		if len(values) == 2 && len(fetched) != 1 {
+			t.Errorf("Expected a single page to be fetched, got %v", fetched)
+		}
+	}
+	if err := paginator.Err(); err != nil {
+		t.Fatalf("Err returned %v", err)
+	}
+	if len(values) != 3 || values[0] != 1 || values[2] != 3 {
+		t.Errorf("Unexpected values: %v", values)
+	}
+	if len(fetched) != 3 {
+		t.Errorf("Expected 3 pages to be fetched, got %v", fetched)
+	}
+	if paginator.Next() || paginator.Value() != 0 {
+		t.Error("Expected the iteration to stay done")
+	}
+}
+
+func TestPaginatorErrors(t *testing.T) {
+	failure := errors.New("page failed")
+	paginator := NewPaginator(context.Background(), func(ctx context.Context, page int) ([]int, bool, error) {
+		if page == 2 {
+			return nil, false, failure
+		}
+		return []int{page}, true, nil
+	})
+	var count int
+	for paginator.Next() {
+		count++
+	}
+	if count != 1 || !errors.Is(paginator.Err(), failure) {
+		t.Errorf("Expected the fetch error after 1 value, got %d values and %v", count, paginator.Err())
+	}
+	if paginator.Next() {
+		t.Error("Expected Next to return false after an error")
+	}
+
+	// The context is checked before every fetch
+	ctx, cancel := context.WithCancel(context.Background())
+	var fetched []int
+	paginator = NewPaginator(ctx, syntheticPages([][]int{{1}, {2}}, &fetched))
+	if !paginator.Next() {
+		t.Fatalf("Expected a first value, got %v", paginator.Err())
+	}
+	cancel()
+	if paginator.Next() || !errors.Is(paginator.Err(), context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", paginator.Err())
+	}
+	if len(fetched) != 1 {
+		t.Errorf("Expected no fetch after cancellation, got %v", fetched)
+	}
+}
+
+func TestPaginatorPrefetch(t *testing.T) {
+	failure := errors.New("page failed")
+	fetched := make(chan int, 10)
+	paginator := NewPaginator(context.Background(), func(ctx context.Context, page int) ([]int, bool, error) {
+		fetched <- page
+		if page == 3 {
+			return nil, false, failure
+		}
+		return []int{page * 10, page*10 + 1}, true, nil
+	}, WithPagePrefetch())
+	defer paginator.Close()
+
+	if !paginator.Next() || paginator.Value() != 10 {
+		t.Fatalf("Expected the first value, got %d and %v", paginator.Value(), paginator.Err())
+	}
+	// The second page is fetched while the first one is processed
+	for _, expected := range []int{1, 2} {
+		select {
+		case page := <-fetched:
+			if page != expected {
+				t.Fatalf("Expected page %d to be fetched, got %d", expected, page)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected page %d to be prefetched", expected)
+		}
+	}
+
+	values := []int{paginator.Value()}
+	for paginator.Next() {
+		values = append(values, paginator.Value())
+	}
+	// The prefetch error surfaces once the values fetched before it are consumed
+	if len(values) != 4 || values[3] != 21 {
+		t.Errorf("Unexpected values: %v", values)
+	}
+	if !errors.Is(paginator.Err(), failure) {
+		t.Errorf("Expected the prefetch error, got %v", paginator.Err())
+	}
+	if paginator.Next() {
+		t.Error("Expected Next to return false after an error")
+	}
+}
+
+func TestPaginatorPrefetchClose(t *testing.T) {
+	fetched := make(chan int, 10)
+	paginator := NewPaginator(context.Background(), func(ctx context.Context, page int) ([]int, bool, error) {
+		fetched <- page
+		return []int{page}, true, nil
+	}, WithPagePrefetch())
+
+	if !paginator.Next() {
+		t.Fatalf("Expected a first value, got %v", paginator.Err())
+	}
+	paginator.Close()
+	if paginator.Next() || paginator.Err() != nil {
+		t.Errorf("Expected the iteration to stop without error, got %v", paginator.Err())
+	}
+	if len(fetched) > 2 {
+		t.Errorf("Expected at most the page in flight to be prefetched, got %d pages fetched", len(fetched))
+	}
+}
+
+func TestPaginatorPrefetchAbandoned(t *testing.T) {
+	var fetched atomic.Int32
+	paginator := NewPaginator(context.Background(), func(ctx context.Context, page int) ([]int, bool, error) {
+		fetched.Add(1)
+		return []int{page}, true, nil
+	}, WithPagePrefetch())
+
+	// The iteration stops without Close: the background fetch of the next page ends on
+	// its own, its page waiting in the buffered channel
+	if !paginator.Next() {
+		t.Fatalf("Expected a first value, got %v", paginator.Err())
+	}
+	select {
+	case result := <-paginator.pending:
+		if len(result.items) != 1 || result.items[0] != 2 {
+			t.Errorf("Expected the second page to be prefetched, got %v", result.items)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the background fetch to end")
+	}
+	time.Sleep(10 * time.Millisecond)
+	if n := fetched.Load(); n != 2 {
+		t.Errorf("Expected a single page to be prefetched, got %d pages fetched", n)
+	}
+}
+
+func TestPaginatorConcurrentClose(t *testing.T) {
+	fetching := make(chan struct{})
+	paginator := NewPaginator(context.Background(), func(ctx context.Context, page int) ([]int, bool, error) {
+		if page == 1 {
+			return []int{1}, true, nil
+		}
+		// The second page hangs until the fetch is cancelled
+		close(fetching)
+		<-ctx.Done()
+		return nil, false, ctx.Err()
+	})
+
+	if !paginator.Next() {
+		t.Fatalf("Expected a first value, got %v", paginator.Err())
+	}
+	go func() {
+		<-fetching
+		paginator.Close()
+	}()
+	if paginator.Next() {
+		t.Error("Expected Close to stop the iteration")
+	}
+	if err := paginator.Err(); err != nil || paginator.Value() != 0 {
+		t.Errorf("Expected a closed iteration without error, got %v and %d", err, paginator.Value())
+	}
+	paginator.Close()
+}
+
+func TestSearchCompaniesIterator(t *testing.T) {
+	server := newPaginatedCompaniesServer(t, 25, 10)
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	page := float32(2)
+	companies := client.SearchCompaniesIterator(context.Background(), &SearchCompaniesParams{Page: &page})
+	var domains []string
+	for companies.Next() {
+		domains = append(domains, companies.Value().DomainName())
+	}
+	if err := companies.Err(); err != nil {
+		t.Fatalf("Err returned %v", err)
+	}
+	if len(domains) != 15 || domains[0] != "company-10.com" || domains[14] != "company-24.com" {
+		t.Errorf("Expected the companies from the second page, got %v", domains)
+	}
+
+	listed := client.CompaniesInListIterator(context.Background(), 1, nil)
+	var count int
+	for listed.Next() {
+		count++
+	}
+	if count != 25 || listed.Err() != nil {
+		t.Errorf("Expected 25 listed companies, got %d and %v", count, listed.Err())
+	}
+
+	prefetched := client.SearchCompaniesIterator(context.Background(), nil, WithPagePrefetch())
+	defer prefetched.Close()
+	count = 0
+	for prefetched.Next() {
+		count++
+	}
+	if count != 25 || prefetched.Err() != nil {
+		t.Errorf("Expected 25 prefetched companies, got %d and %v", count, prefetched.Err())
+	}
+}
+
+func TestSearchCompaniesIteratorShortPage(t *testing.T) {
+	requests := map[int]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var page int
+		fmt.Sscan(r.URL.Query().Get("page"), &page)
+		requests[page]++
+
+		// 25 companies by pages of 10, the first response of page 2 missing companies
+		count := min(10, 25-(page-1)*10)
+		if page == 2 && requests[page] == 1 {
+			count = 4
+		}
+		companies := make([]map[string]any, count)
+		for i := range companies {
+			companies[i] = map[string]any{"domain": map[string]any{"domain": fmt.Sprintf("company-%d.com", (page-1)*10+i)}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"companies": companies,
+			"meta":      map[string]any{"currentPage": page, "lastPage": 3, "perPage": 10, "total": 25},
+		})
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+	companies := client.SearchCompaniesIterator(context.Background(), nil)
+	var count int
+	for companies.Next() {
+		count++
+	}
+	if err := companies.Err(); err != nil {
+		t.Fatalf("Err returned %v", err)
+	}
+	if count != 25 {
+		t.Errorf("Expected the short page to be fetched again, got %d companies", count)
+	}
+	// The last page is short because the results end with it
+	if requests[1] != 1 || requests[2] != 2 || requests[3] != 1 {
+		t.Errorf("Unexpected requests per page: %v", requests)
+	}
+}
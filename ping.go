@@ -0,0 +1,42 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DefaultPingTimeout bounds the duration of Ping when the context has no earlier deadline
+const DefaultPingTimeout = 5 * time.Second
+
+var (
+	// ErrUnauthorized is matched by the Ping error when the API rejects the credentials
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrUnreachable is matched by the Ping error when the API cannot be reached
+	ErrUnreachable = errors.New("api unreachable")
+)
+
+// Ping checks that the API is reachable and the client authenticated, e.g. for the
+// readiness probe of a service. It fetches the current user since FetchApiHealth does
+// not check credentials. The error matches ErrUnauthorized for 401 and 403 responses
+// and ErrUnreachable when no response was received, including on timeout; other
+// unsuccessful responses are returned as is.
+func (c *CompaniesAPIClient) Ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, DefaultPingTimeout)
+	defer cancel()
+
+	response, err := c.FetchUser(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUnreachable, err)
+	}
+	switch response.StatusCode() {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %w", ErrUnauthorized, c.baseClient.responseError(response.HTTPResponse, response.Body))
+	default:
+		return c.baseClient.responseError(response.HTTPResponse, response.Body)
+	}
+}
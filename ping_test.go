@@ -0,0 +1,42 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") != "Basic valid-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"unauthorized","message":"Invalid API token"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	healthy, _ := ApiClient("valid-key", WithCustomBaseURL(server.URL))
+	if err := healthy.Ping(context.Background()); err != nil {
+		t.Errorf("Expected healthy ping, got %v", err)
+	}
+
+	unauthorized, _ := ApiClient("invalid-key", WithCustomBaseURL(server.URL))
+	err := unauthorized.Ping(context.Background())
+	var apiErr *Error
+	if !errors.Is(err, ErrUnauthorized) || errors.Is(err, ErrUnreachable) || !errors.As(err, &apiErr) {
+		t.Errorf("Expected ErrUnauthorized wrapping the API error, got %v", err)
+	}
+
+	// A closed server refuses connections
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	unreachable, _ := ApiClient("valid-key", WithCustomBaseURL(closed.URL))
+	if err := unreachable.Ping(context.Background()); !errors.Is(err, ErrUnreachable) || errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected ErrUnreachable, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package thecompaniesapi
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// FieldPresence tells how a field appears in a JSON response
+type FieldPresence int
+
+const (
+	// FieldMissing means the field was omitted, e.g. not part of a simplified response
+	FieldMissing FieldPresence = iota
+	// FieldNull means the field was returned as null: the API has no data for it
+	FieldNull
+	// FieldPresent means the field was returned with a value
+	FieldPresent
+)
+
+func (p FieldPresence) String() string {
+	switch p {
+	case FieldNull:
+		return "null"
+	case FieldPresent:
+		return "present"
+	default:
+		return "missing"
+	}
+}
+
+// CompanyFieldPresence reports how the top-level fields of a company (about, domain,
+// finances...) appear in its raw JSON, which the decoded Company cannot tell apart as
+// both omitted and null fields decode to nil. Fields absent from the map are missing.
+func CompanyFieldPresence(raw []byte) (map[string]FieldPresence, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	presence := make(map[string]FieldPresence, len(fields))
+	for name, value := range fields {
+		if bytes.Equal(bytes.TrimSpace(value), []byte("null")) {
+			presence[name] = FieldNull
+		} else {
+			presence[name] = FieldPresent
+		}
+	}
+	return presence, nil
+}
+
+// FieldPresence reports the presence of the top-level fields of every company of the
+// response, in order, from its raw body (see CompanyFieldPresence)
+func (r *SearchCompaniesResponse) FieldPresence() ([]map[string]FieldPresence, error) {
+	return companiesFieldPresence(r.Body)
+}
+
+// FieldPresence reports the presence of the top-level fields of every company of the
+// response, in order, from its raw body (see CompanyFieldPresence)
+func (r *SearchCompaniesPostResponse) FieldPresence() ([]map[string]FieldPresence, error) {
+	return companiesFieldPresence(r.Body)
+}
+
+// companiesFieldPresence reports the field presence of the companies of a search body
+func companiesFieldPresence(body []byte) ([]map[string]FieldPresence, error) {
+	var response struct {
+		Companies []json.RawMessage `json:"companies"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+	presence := make([]map[string]FieldPresence, len(response.Companies))
+	for i, company := range response.Companies {
+		fields, err := CompanyFieldPresence(company)
+		if err != nil {
+			return nil, err
+		}
+		presence[i] = fields
+	}
+	return presence, nil
+}
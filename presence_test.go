@@ -0,0 +1,58 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearchCompaniesFieldPresence(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("simplified") == "true" {
+			w.Write([]byte(`{"companies":[{"about":{"name":"Apple"},"domain":{"domain":"apple.com"}}],"meta":{}}`))
+			return
+		}
+		w.Write([]byte(`{"companies":[{"about":{"name":"Apple"},"domain":{"domain":"apple.com"},"finances":null,"technologies":{"active":[]}}],"meta":{}}`))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+	ctx := context.Background()
+
+	full, err := client.SearchCompanies(ctx, nil)
+	if err != nil {
+		t.Fatalf("SearchCompanies returned error: %v", err)
+	}
+	fullPresence, err := full.FieldPresence()
+	if err != nil || len(fullPresence) != 1 {
+		t.Fatalf("FieldPresence returned %v, %v", fullPresence, err)
+	}
+
+	simplified := true
+	partial, err := client.SearchCompanies(ctx, &SearchCompaniesParams{Simplified: &simplified})
+	if err != nil {
+		t.Fatalf("SearchCompanies returned error: %v", err)
+	}
+	partialPresence, err := partial.FieldPresence()
+	if err != nil || len(partialPresence) != 1 {
+		t.Fatalf("FieldPresence returned %v, %v", partialPresence, err)
+	}
+
+	// Both decode finances to nil, only the raw body tells "no data" from "not requested"
+	expected := map[string][2]FieldPresence{
+		"about":        {FieldPresent, FieldPresent},
+		"finances":     {FieldNull, FieldMissing},
+		"technologies": {FieldPresent, FieldMissing},
+		"people":       {FieldMissing, FieldMissing},
+	}
+	for field, presence := range expected {
+		if fullPresence[0][field] != presence[0] || partialPresence[0][field] != presence[1] {
+			t.Errorf("%s: expected %v and %v, got %v and %v", field, presence[0], presence[1], fullPresence[0][field], partialPresence[0][field])
+		}
+	}
+}
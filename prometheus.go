@@ -0,0 +1,108 @@
+//go:build prometheus
+
+package thecompaniesapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// metricsNamespace prefixes the names of the Prometheus metrics of the SDK
+	metricsNamespace = "thecompaniesapi"
+	// unknownOperation labels the requests matching no operation of the API
+	unknownOperation = "unknown"
+)
+
+// WithPrometheus registers Prometheus collectors on registerer and records every
+// request in them, labeled by API operation (e.g. "FetchCompany") and response status:
+//
+//   - thecompaniesapi_requests_total counts the requests
+//   - thecompaniesapi_request_errors_total counts the transport errors and the
+//     responses with a status >= 400
+//   - thecompaniesapi_request_duration_seconds is a histogram of the request latency
+//
+// The status label is "error" for requests that failed without a response, and the
+// operation label "unknown" for requests matching no operation of the API, so that the
+// paths of the requests never end up in labels. Clients sharing a registerer share its
+// collectors; ApiClient returns an error when the collectors cannot be registered,
+// e.g. when metrics of the same names but other labels are already registered.
+//
+// This option is only available when building with the "prometheus" build tag so
+// that Prometheus stays an optional dependency.
+func WithPrometheus(registerer prometheus.Registerer) BaseClientOption {
+	return func(c *BaseClient) {
+		labels := []string{"operation", "status"}
+		requests, err := registerCollector(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "requests_total",
+			Help:      "Requests sent to The Companies API.",
+		}, labels))
+		if err != nil {
+			c.setOptionError(err)
+			return
+		}
+		failures, err := registerCollector(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "request_errors_total",
+			Help:      "Requests to The Companies API that failed or returned a status >= 400.",
+		}, labels))
+		if err != nil {
+			c.setOptionError(err)
+			return
+		}
+		latency, err := registerCollector(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of the requests sent to The Companies API.",
+			Buckets:   prometheus.DefBuckets,
+		}, labels))
+		if err != nil {
+			c.setOptionError(err)
+			return
+		}
+
+		c.middlewares = append(c.middlewares, func(next roundTripFunc) roundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				start := time.Now()
+				resp, err := next(req)
+
+				operation, ok := requestOperation(req)
+				if !ok {
+					operation = unknownOperation
+				}
+				status := "error"
+				if err == nil {
+					status = strconv.Itoa(resp.StatusCode)
+				}
+				values := []string{operation, status}
+				requests.WithLabelValues(values...).Inc()
+				latency.WithLabelValues(values...).Observe(time.Since(start).Seconds())
+				if err != nil || resp.StatusCode >= 400 {
+					failures.WithLabelValues(values...).Inc()
+				}
+				return resp, err
+			}
+		})
+	}
+}
+
+// registerCollector registers collector on registerer, returning the collector already
+// registered under the same name when there is one
+func registerCollector[C prometheus.Collector](registerer prometheus.Registerer, collector C) (C, error) {
+	if err := registerer.Register(collector); err != nil {
+		var registered prometheus.AlreadyRegisteredError
+		if errors.As(err, &registered) {
+			if existing, ok := registered.ExistingCollector.(C); ok {
+				return existing, nil
+			}
+		}
+		return collector, fmt.Errorf("failed to register Prometheus collector: %w", err)
+	}
+	return collector, nil
+}
@@ -0,0 +1,131 @@
+//go:build prometheus
+
+package thecompaniesapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestWithPrometheus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/companies/unknown.com" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"count":42}`))
+	}))
+	defer server.Close()
+
+	registry := prometheus.NewRegistry()
+	client, err := ApiClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithPrometheus(registry),
+	)
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := client.CountCompanies(ctx, &CountCompaniesParams{}); err != nil {
+		t.Fatalf("CountCompanies returned error: %v", err)
+	}
+	if _, err := client.FetchCompany(ctx, "unknown.com", nil); err != nil {
+		t.Fatalf("FetchCompany returned error: %v", err)
+	}
+
+	// A second client registering on the same registry shares the collectors
+	other, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL), WithPrometheus(registry))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+	if _, err := other.CountCompanies(ctx, &CountCompaniesParams{}); err != nil {
+		t.Fatalf("CountCompanies returned error: %v", err)
+	}
+
+	// The value of a counter or the sample count of a histogram
+	counter := func(name, operation, status string) float64 {
+		t.Helper()
+		families, _ := registry.Gather()
+		for _, family := range families {
+			if family.GetName() != metricsNamespace+"_"+name {
+				continue
+			}
+			for _, metric := range family.GetMetric() {
+				labels := map[string]string{}
+				for _, label := range metric.GetLabel() {
+					labels[label.GetName()] = label.GetValue()
+				}
+				if labels["operation"] != operation || labels["status"] != status {
+					continue
+				}
+				if histogram := metric.GetHistogram(); histogram != nil {
+					return float64(histogram.GetSampleCount())
+				}
+				return metric.GetCounter().GetValue()
+			}
+		}
+		return 0
+	}
+
+	if count := counter("requests_total", "CountCompanies", "200"); count != 2 {
+		t.Errorf("Expected 2 CountCompanies requests, got %v", count)
+	}
+	if count := counter("requests_total", "FetchCompany", "404"); count != 1 {
+		t.Errorf("Expected 1 FetchCompany request, got %v", count)
+	}
+	if count := counter("request_errors_total", "FetchCompany", "404"); count != 1 {
+		t.Errorf("Expected 1 FetchCompany error, got %v", count)
+	}
+	if count := counter("request_errors_total", "CountCompanies", "200"); count != 0 {
+		t.Errorf("Expected no CountCompanies error, got %v", count)
+	}
+	if count := counter("request_duration_seconds", "CountCompanies", "200"); count != 2 {
+		t.Errorf("Expected 2 CountCompanies latency samples, got %v", count)
+	}
+	// Operations are resolved relative to the path of the base URL, and the requests
+	// matching none are labeled "unknown" rather than by path
+	prefixed, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL+"/tca/"), WithPrometheus(registry))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+	if _, err := prefixed.FetchCompany(ctx, "apple.com", nil); err != nil {
+		t.Fatalf("FetchCompany returned error: %v", err)
+	}
+	if _, err := prefixed.baseClient.MakeRequest(ctx, "GET", "/v2/unknown/apple.com", nil); err != nil {
+		t.Fatalf("MakeRequest returned error: %v", err)
+	}
+	if count := counter("requests_total", "FetchCompany", "200"); count != 1 {
+		t.Errorf("Expected 1 prefixed FetchCompany request, got %v", count)
+	}
+	if count := counter("requests_total", "unknown", "200"); count != 1 {
+		t.Errorf("Expected 1 unknown request, got %v", count)
+	}
+
+	if problems, err := testutil.GatherAndLint(registry); err != nil || len(problems) > 0 {
+		t.Errorf("Expected valid metrics, got %v, %v", problems, err)
+	}
+}
+
+func TestWithPrometheusRegistrationError(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "requests_total",
+		Help:      "Requests sent to The Companies API.",
+	}, []string{"endpoint"}))
+
+	if _, err := ApiClient("test-api-key", WithPrometheus(registry)); err == nil {
+		t.Error("Expected an error for conflicting collectors")
+	}
+	client := NewBaseClient("test-api-key", WithPrometheus(registry))
+	if _, err := client.MakeRequest(context.Background(), "GET", "/", nil); err == nil {
+		t.Error("Expected requests to fail for conflicting collectors")
+	}
+}
@@ -0,0 +1,116 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrNoSegmentation is returned when a prompt could not be translated to segmentation
+// conditions
+var ErrNoSegmentation = errors.New("prompt produced no segmentation")
+
+// SavedSearch is the result of PromptToSavedSearch
+type SavedSearch struct {
+	// List is the list the companies were added to
+	List *List
+	// Created reports whether the list was created by PromptToSavedSearch
+	Created bool
+	// Query is the segmentation the prompt was translated to
+	Query []SegmentationCondition
+	// Domains are the domains of the companies found by the search and added to the list
+	Domains []string
+}
+
+// PromptToSavedSearch runs the "describe your ICP, get a list" workflow: the prompt is
+// translated to segmentation conditions with PromptToSegmentation, the first
+// MaxSearchSize companies matching them are searched with SearchCompaniesPost and
+// added to the list named listName, which is created when FindListByName finds none.
+//
+// Errors are prefixed with the failing stage (segment, search, find list, create list
+// or add companies) and ErrNoSegmentation is returned when the prompt yields no
+// conditions. As with DeleteListByName, the stages share the deadline of ctx.
+func (c *CompaniesAPIClient) PromptToSavedSearch(ctx context.Context, prompt, listName string) (*SavedSearch, error) {
+	budget := c.newCallBudget(ctx, 4)
+	var result SavedSearch
+
+	stepCtx, cancel := budget.next(ctx)
+	segmentation, err := c.PromptToSegmentation(stepCtx, PromptToSegmentationJSONRequestBody{Prompt: prompt})
+	cancel()
+	if err == nil && (segmentation.StatusCode() != http.StatusOK || segmentation.JSON200 == nil) {
+		err = c.baseClient.responseError(segmentation.HTTPResponse, segmentation.Body)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("segment: %w", err)
+	}
+	response := segmentation.JSON200.Response
+	if response.Error != nil && *response.Error != "" {
+		return nil, fmt.Errorf("segment: %w: %s", ErrNoSegmentation, *response.Error)
+	}
+	if response.Query == nil || len(*response.Query) == 0 {
+		return nil, fmt.Errorf("segment: %w", ErrNoSegmentation)
+	}
+	result.Query = *response.Query
+
+	stepCtx, cancel = budget.next(ctx)
+	size := float32(MaxSearchSize)
+	search, err := c.SearchCompaniesPost(stepCtx, SearchCompaniesPostJSONRequestBody{Query: &result.Query, Size: &size})
+	cancel()
+	if err == nil && (search.StatusCode() != http.StatusOK || search.JSON200 == nil) {
+		err = c.baseClient.responseError(search.HTTPResponse, search.Body)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+	for _, company := range search.JSON200.Companies {
+		if domain := company.DomainName(); domain != "" {
+			result.Domains = append(result.Domains, domain)
+		}
+	}
+
+	stepCtx, cancel = budget.next(ctx)
+	result.List, result.Created, err = c.findOrCreateList(stepCtx, listName)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Domains) == 0 {
+		return &result, nil
+	}
+
+	stepCtx, cancel = budget.next(ctx)
+	defer cancel()
+	toggled, err := c.ToggleCompaniesInList(stepCtx, result.List.Id, ToggleCompaniesInListJSONRequestBody{
+		Action:  Attach,
+		Domains: &result.Domains,
+	})
+	if err == nil && (toggled.StatusCode() != http.StatusOK || toggled.JSON200 == nil) {
+		err = c.baseClient.responseError(toggled.HTTPResponse, toggled.Body)
+	}
+	if err != nil {
+		return &result, fmt.Errorf("add companies: %w", err)
+	}
+	result.List = toggled.JSON200
+	return &result, nil
+}
+
+// findOrCreateList returns the list named name, creating it when there is none
+func (c *CompaniesAPIClient) findOrCreateList(ctx context.Context, name string) (list *List, created bool, err error) {
+	list, err = c.FindListByName(ctx, name)
+	if err == nil {
+		return list, false, nil
+	}
+	if !errors.Is(err, ErrListNotFound) {
+		return nil, false, fmt.Errorf("find list: %w", err)
+	}
+
+	response, err := c.CreateList(ctx, NewListCreate(name))
+	if err == nil && (response.StatusCode() != http.StatusOK || response.JSON200 == nil) {
+		err = c.baseClient.responseError(response.HTTPResponse, response.Body)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("create list: %w", err)
+	}
+	return response.JSON200, true, nil
+}
@@ -0,0 +1,90 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPromptToSavedSearch(t *testing.T) {
+	segmentation := `{"prompt":{},"meta":{},"response":{"query":[{"attribute":"about.industries","operator":"and","sign":"equals","values":["software"]}]}}`
+	lists := `{"lists":[{"id":1,"name":"Customers"}],"meta":{"currentPage":1,"lastPage":1}}`
+	var (
+		createdName string
+		attached    []string
+		searched    []map[string]any
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v2/prompts/segmentation":
+			w.Write([]byte(segmentation))
+		case r.URL.Path == "/v2/companies":
+			var body struct {
+				Query []map[string]any `json:"query"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			searched = body.Query
+			w.Write([]byte(`{"companies":[{"domain":{"domain":"acme.com"}},{"domain":{"domain":"globex.com"}}],"meta":{}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/lists":
+			w.Write([]byte(lists))
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/lists":
+			var body CreateListJSONRequestBody
+			json.NewDecoder(r.Body).Decode(&body)
+			createdName = body.Name
+			w.Write([]byte(`{"id":2,"name":"Software"}`))
+		case r.URL.Path == "/v2/lists/2/companies/toggle":
+			var body ToggleCompaniesInListJSONRequestBody
+			json.NewDecoder(r.Body).Decode(&body)
+			if body.Action != Attach || body.Domains == nil {
+				t.Errorf("Unexpected toggle body: %+v", body)
+			} else {
+				attached = *body.Domains
+			}
+			w.Write([]byte(`{"id":2,"name":"Software"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	result, err := client.PromptToSavedSearch(context.Background(), "software companies", "Software")
+	if err != nil {
+		t.Fatalf("PromptToSavedSearch returned error: %v", err)
+	}
+	if len(searched) != 1 || searched[0]["attribute"] != "about.industries" {
+		t.Errorf("Expected the search to use the segmentation, got %v", searched)
+	}
+	if !result.Created || createdName != "Software" || result.List.Id != 2 {
+		t.Errorf("Expected the list to be created, got %+v", result)
+	}
+	if len(attached) != 2 || attached[0] != "acme.com" || attached[1] != "globex.com" {
+		t.Errorf("Expected the search results to be added, got %v", attached)
+	}
+
+	// An existing list is reused
+	createdName = ""
+	lists = `{"lists":[{"id":2,"name":"Software"}],"meta":{"currentPage":1,"lastPage":1}}`
+	if result, err := client.PromptToSavedSearch(context.Background(), "software companies", "Software"); err != nil || result.Created || createdName != "" {
+		t.Errorf("Expected the existing list to be reused, got %+v, %v", result, err)
+	}
+
+	// Stages surface their errors
+	segmentation = `{"prompt":{},"meta":{},"response":{"error":"unsupported prompt"}}`
+	if _, err := client.PromptToSavedSearch(context.Background(), "weather", "Software"); !errors.Is(err, ErrNoSegmentation) || err.Error() != "segment: prompt produced no segmentation: unsupported prompt" {
+		t.Errorf("Expected ErrNoSegmentation, got %v", err)
+	}
+	segmentation = `{"prompt":{},"meta":{},"response":{"query":[{"attribute":"about.industries","operator":"and","sign":"equals","values":["software"]}]}}`
+	lists = `{"lists":[{"id":2,"name":"Software"},{"id":3,"name":"Software"}],"meta":{"currentPage":1,"lastPage":1}}`
+	if _, err := client.PromptToSavedSearch(context.Background(), "software companies", "Software"); !errors.Is(err, ErrAmbiguousListName) {
+		t.Errorf("Expected ErrAmbiguousListName, got %v", err)
+	}
+}
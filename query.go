@@ -0,0 +1,82 @@
+package thecompaniesapi
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// QueryArrayFormat defines how BuildQueryString serializes arrays
+type QueryArrayFormat string
+
+const (
+	// QueryArrayJSON encodes arrays as JSON: x=["a","b"] (default)
+	QueryArrayJSON QueryArrayFormat = "json"
+	// QueryArrayRepeat repeats the key for each element: x=a&x=b
+	QueryArrayRepeat QueryArrayFormat = "repeat"
+	// QueryArrayComma joins the elements with commas: x=a,b
+	QueryArrayComma QueryArrayFormat = "comma"
+)
+
+// WithQueryArrayFormat sets how BuildQueryString and MakeRequestWithQuery serialize
+// arrays of primitive values (strings, numbers, booleans), to match the expectations
+// of an endpoint. Objects, and arrays holding objects or arrays such as the query
+// conditions of FetchCompaniesAnalyticsResult, are still sent as a single JSON
+// encoded parameter.
+func WithQueryArrayFormat(format QueryArrayFormat) BaseClientOption {
+	return func(c *BaseClient) {
+		c.queryArrayFormat = format
+	}
+}
+
+// encodeQueryArray serializes an array parameter according to the array format of the
+// client. It reports false when the value is not an array of primitive values or the
+// format is JSON. Empty arrays result in an empty string.
+func (c *BaseClient) encodeQueryArray(key string, value interface{}) (string, bool) {
+	if c.queryArrayFormat != QueryArrayRepeat && c.queryArrayFormat != QueryArrayComma {
+		return "", false
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", false
+	}
+
+	elements := make([]string, v.Len())
+	for i := range elements {
+		element, ok := queryElement(v.Index(i).Interface())
+		if !ok {
+			return "", false
+		}
+		elements[i] = url.QueryEscape(element)
+	}
+	if len(elements) == 0 {
+		return "", true
+	}
+
+	encodedKey := url.QueryEscape(key)
+	if c.queryArrayFormat == QueryArrayComma {
+		return encodedKey + "=" + strings.Join(elements, ","), true
+	}
+	return encodedKey + "=" + strings.Join(elements, "&"+encodedKey+"="), true
+}
+
+// queryElement converts a primitive array element to its query representation,
+// reporting false for objects and arrays which are to be JSON encoded with their array
+func queryElement(element interface{}) (string, bool) {
+	value := reflect.Indirect(reflect.ValueOf(element))
+	switch value.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array, reflect.Interface:
+		return "", false
+	case reflect.Invalid:
+		return "", true
+	}
+	return fmt.Sprint(value.Interface()), true
+}
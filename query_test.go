@@ -0,0 +1,79 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithQueryArrayFormat(t *testing.T) {
+	fields := []string{"about.name", "domain.domain"}
+	params := map[string]interface{}{
+		"searchFields": fields,
+		"page":         1,
+	}
+
+	tests := []struct {
+		format   QueryArrayFormat
+		params   map[string]interface{}
+		expected string
+	}{
+		{QueryArrayJSON, params, `page=1&searchFields=%5B%22about.name%22%2C%22domain.domain%22%5D`},
+		{QueryArrayRepeat, params, `page=1&searchFields=about.name&searchFields=domain.domain`},
+		{QueryArrayComma, params, `page=1&searchFields=about.name,domain.domain`},
+		{QueryArrayRepeat, map[string]interface{}{"ids": &[]float32{1, 2.5}}, `ids=1&ids=2.5`},
+		{QueryArrayComma, map[string]interface{}{"values": []string{"a,b", "c d"}}, `values=a%2Cb,c+d`},
+		{QueryArrayRepeat, map[string]interface{}{"empty": []string{}, "search": "saas"}, `search=saas`},
+		// Arrays of objects stay JSON encoded as a whole
+		{QueryArrayRepeat, map[string]interface{}{"query": []map[string]string{{"sign": "equals"}, {"sign": "not"}}}, `query=%5B%7B%22sign%22%3A%22equals%22%7D%2C%7B%22sign%22%3A%22not%22%7D%5D`},
+	}
+
+	for _, tt := range tests {
+		client := NewBaseClient("test-api-key", WithQueryArrayFormat(tt.format))
+		if result := client.BuildQueryString(tt.params); result != tt.expected {
+			t.Errorf("%s: BuildQueryString() = %v, expected %v", tt.format, result, tt.expected)
+		}
+	}
+}
+
+func TestQueryArrayFormatAnalyticsQuery(t *testing.T) {
+	var queries [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query()["query"])
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[],"meta":{}}`))
+	}))
+	defer server.Close()
+
+	var value SegmentationCondition_Values_Item
+	value.FromSegmentationConditionValues0("software")
+	query := []SegmentationCondition{
+		testCondition(SegmentationConditionAttributeAboutIndustries, And),
+		testCondition(SegmentationConditionAttributeAboutName, And),
+	}
+	query[0].Values = []SegmentationCondition_Values_Item{value}
+	query[1].Values = []SegmentationCondition_Values_Item{value}
+
+	for _, format := range []QueryArrayFormat{QueryArrayJSON, QueryArrayRepeat, QueryArrayComma} {
+		queries = nil
+		client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL), WithQueryArrayFormat(format))
+		if err != nil {
+			t.Fatalf("ApiClient returned error: %v", err)
+		}
+		params := &FetchCompaniesAnalyticsParams{Query: &query}
+		if _, err := client.FetchCompaniesAnalyticsResult(context.Background(), params, FetchCompaniesAnalyticsParamsAttributeAboutIndustry); err != nil {
+			t.Fatalf("%s: FetchCompaniesAnalyticsResult returned error: %v", format, err)
+		}
+
+		// The conditions are sent as a single JSON encoded parameter whatever the format
+		if len(queries) != 1 || len(queries[0]) != 1 {
+			t.Fatalf("%s: expected a single query parameter, got %v", format, queries)
+		}
+		var sent []SegmentationCondition
+		if err := json.Unmarshal([]byte(queries[0][0]), &sent); err != nil || len(sent) != 2 {
+			t.Errorf("%s: expected the JSON encoded conditions, got %q (%v)", format, queries[0][0], err)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package thecompaniesapi
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit is the rate limit state sent by the API in the X-RateLimit-Limit,
+// X-RateLimit-Remaining and X-RateLimit-Reset response headers (or their RateLimit-*
+// equivalents)
+type RateLimit struct {
+	// Limit is the number of requests allowed in the current window, 0 when unknown
+	Limit int
+	// Remaining is the number of requests left in the current window
+	Remaining int
+	// Reset is the time left until the window resets, 0 when unknown
+	Reset time.Duration
+}
+
+// ParseRateLimit extracts the rate limit state of a response, reporting false when
+// the response has no remaining requests header. The reset may be sent as a number of
+// seconds or as a Unix timestamp.
+func ParseRateLimit(header http.Header) (RateLimit, bool) {
+	return parseRateLimit(header, time.Now())
+}
+
+func parseRateLimit(header http.Header, now time.Time) (RateLimit, bool) {
+	remaining, ok := rateLimitHeader(header, "Remaining")
+	if !ok {
+		return RateLimit{}, false
+	}
+	rateLimit := RateLimit{Remaining: int(remaining)}
+	if limit, ok := rateLimitHeader(header, "Limit"); ok {
+		rateLimit.Limit = int(limit)
+	}
+	if reset, ok := rateLimitHeader(header, "Reset"); ok {
+		// Values beyond a year of seconds are timestamps
+		if reset > 365*24*60*60 {
+			reset = float64(time.Unix(int64(reset), 0).Sub(now)) / float64(time.Second)
+		}
+		if reset > 0 {
+			rateLimit.Reset = scaleDelay(time.Second, reset)
+		}
+	}
+	return rateLimit, true
+}
+
+// rateLimitHeader reads a numeric X-RateLimit-<name> or RateLimit-<name> header
+func rateLimitHeader(header http.Header, name string) (float64, bool) {
+	for _, key := range []string{"X-RateLimit-" + name, "RateLimit-" + name} {
+		value := strings.TrimSpace(header.Get(key))
+		if value == "" {
+			continue
+		}
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil || number < 0 || math.IsInf(number, 0) {
+			return 0, false
+		}
+		return number, true
+	}
+	return 0, false
+}
+
+// autoThrottleReserve is the share of the rate limit below which WithAutoThrottle
+// spreads the remaining requests over the rest of the window
+const autoThrottleReserve = 0.1
+
+// WithAutoThrottle delays requests according to the rate limit headers of the previous
+// responses instead of waiting to be answered with 429 Too Many Requests. Once the
+// remaining requests fall below a tenth of the limit, they are spread evenly until the
+// window resets, and when none is left requests wait for the reset. The wait stops
+// with the error of the request context once it is done.
+func WithAutoThrottle() BaseClientOption {
+	return func(c *BaseClient) {
+		throttle := &autoThrottle{now: time.Now}
+		c.middlewares = append(c.middlewares, func(next roundTripFunc) roundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				if delay := throttle.reserve(); delay > 0 {
+					timer := time.NewTimer(delay)
+					select {
+					case <-req.Context().Done():
+						timer.Stop()
+						closeRequestBody(req.Body)
+						return nil, req.Context().Err()
+					case <-timer.C:
+					}
+				}
+				resp, err := next(req)
+				if err == nil {
+					throttle.record(resp.Header)
+				}
+				return resp, err
+			}
+		})
+	}
+}
+
+// autoThrottle tracks the rate limit state of a client
+type autoThrottle struct {
+	mu        sync.Mutex
+	known     bool
+	limit     int
+	remaining int
+	resetAt   time.Time
+	now       func() time.Time
+}
+
+// reserve consumes a request of the current window and returns how long to wait
+// before sending it
+func (t *autoThrottle) reserve() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	if !t.known || !now.Before(t.resetAt) {
+		return 0
+	}
+	left := t.resetAt.Sub(now)
+	if t.remaining <= 0 {
+		return left
+	}
+	low := float64(t.remaining) <= float64(t.limit)*autoThrottleReserve || t.limit <= 0 && t.remaining <= 1
+	spacing := left / time.Duration(t.remaining+1)
+	t.remaining--
+	if !low {
+		return 0
+	}
+	return spacing
+}
+
+// record updates the state with the rate limit headers of a response
+func (t *autoThrottle) record(header http.Header) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	rateLimit, ok := parseRateLimit(header, t.now())
+	if !ok || rateLimit.Reset <= 0 {
+		return
+	}
+	t.known = true
+	t.limit = rateLimit.Limit
+	t.remaining = rateLimit.Remaining
+	t.resetAt = t.now().Add(rateLimit.Reset)
+}
@@ -0,0 +1,93 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	for _, test := range []struct {
+		header   http.Header
+		expected RateLimit
+		ok       bool
+	}{
+		{http.Header{"X-Ratelimit-Limit": {"100"}, "X-Ratelimit-Remaining": {"42"}, "X-Ratelimit-Reset": {"30"}}, RateLimit{Limit: 100, Remaining: 42, Reset: 30 * time.Second}, true},
+		{http.Header{"Ratelimit-Remaining": {"0"}, "Ratelimit-Reset": {"0.5"}}, RateLimit{Reset: 500 * time.Millisecond}, true},
+		{http.Header{"X-Ratelimit-Remaining": {"3"}, "X-Ratelimit-Reset": {strconv.Itoa(1700000060)}}, RateLimit{Remaining: 3, Reset: time.Minute}, true},
+		{http.Header{"X-Ratelimit-Limit": {"100"}}, RateLimit{}, false},
+		{http.Header{"X-Ratelimit-Remaining": {"many"}}, RateLimit{}, false},
+	} {
+		rateLimit, ok := parseRateLimit(test.header, now)
+		if rateLimit != test.expected || ok != test.ok {
+			t.Errorf("parseRateLimit(%v) = %+v, %v, expected %+v, %v", test.header, rateLimit, ok, test.expected, test.ok)
+		}
+	}
+}
+
+func TestWithAutoThrottle(t *testing.T) {
+	var requests []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, time.Now())
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "0.3")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewBaseClient("test-api-key", WithCustomBaseURL(server.URL), WithAutoThrottle())
+	ctx := context.Background()
+
+	// The first request has no rate limit state to wait for
+	start := time.Now()
+	if _, err := client.MakeRequest(ctx, "GET", "/v2/user", nil); err != nil {
+		t.Fatalf("MakeRequest failed: %v", err)
+	}
+	if time.Since(start) > 200*time.Millisecond {
+		t.Errorf("Expected the first request to be sent right away, took %v", time.Since(start))
+	}
+
+	// No request is left in the window: the next one waits for the reset
+	if _, err := client.MakeRequest(ctx, "GET", "/v2/user", nil); err != nil {
+		t.Fatalf("MakeRequest failed: %v", err)
+	}
+	if len(requests) != 2 || requests[1].Sub(requests[0]) < 250*time.Millisecond {
+		t.Errorf("Expected the second request to be delayed until the reset, got %v", requests[1].Sub(requests[0]))
+	}
+
+	// The wait stops with the context
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.MakeRequest(ctx, "GET", "/v2/user", nil); err == nil || ctx.Err() == nil {
+		t.Errorf("Expected the throttled request to be cancelled, got %v", err)
+	}
+	if len(requests) != 2 {
+		t.Errorf("Expected the cancelled request not to be sent, got %d requests", len(requests))
+	}
+}
+
+func TestAutoThrottleSpreadsRemainingRequests(t *testing.T) {
+	now := time.Now()
+	throttle := &autoThrottle{now: func() time.Time { return now }}
+	throttle.record(http.Header{"X-Ratelimit-Limit": {"100"}, "X-Ratelimit-Remaining": {"50"}, "X-Ratelimit-Reset": {"10"}})
+	if delay := throttle.reserve(); delay != 0 {
+		t.Errorf("Expected no delay with plenty of requests left, got %v", delay)
+	}
+
+	throttle.record(http.Header{"X-Ratelimit-Limit": {"100"}, "X-Ratelimit-Remaining": {"4"}, "X-Ratelimit-Reset": {"10"}})
+	if delay := throttle.reserve(); delay != 2*time.Second {
+		t.Errorf("Expected the 4 remaining requests to be spread over 10s, got %v", delay)
+	}
+
+	// The window reset lifts the throttle
+	now = now.Add(11 * time.Second)
+	if delay := throttle.reserve(); delay != 0 {
+		t.Errorf("Expected no delay after the reset, got %v", delay)
+	}
+}
@@ -0,0 +1,102 @@
+package thecompaniesapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrTooManyRedirects is returned when a request is redirected more times than the
+// limit set with WithMaxRedirects
+var ErrTooManyRedirects = errors.New("too many redirects")
+
+// WithMaxRedirects limits the number of redirects followed for a request, failing
+// with ErrTooManyRedirects beyond n instead of after the 10 redirects allowed by the
+// default HTTP client. A limit of 0 rejects any redirect.
+func WithMaxRedirects(n int) BaseClientOption {
+	return func(c *BaseClient) {
+		c.checkRedirect = func(req *http.Request, via []*http.Request) error {
+			if len(via) > n {
+				return fmt.Errorf("%w: stopped after %d redirects", ErrTooManyRedirects, len(via)-1)
+			}
+			return nil
+		}
+	}
+}
+
+// WithNoRedirects disables following redirects: the 3xx response itself is returned,
+// RedirectChain reporting a single URL and its Location header the redirect target
+func WithNoRedirects() BaseClientOption {
+	return func(c *BaseClient) {
+		c.checkRedirect = func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+}
+
+// applyRedirectPolicy installs the redirect policy of WithMaxRedirects or WithNoRedirects
+// on a copy of the HTTP client, leaving a client passed with WithCustomHTTPClient untouched
+func (c *BaseClient) applyRedirectPolicy() {
+	if c.checkRedirect == nil {
+		return
+	}
+	httpClient := *c.httpClient
+	httpClient.CheckRedirect = c.checkRedirect
+	c.httpClient = &httpClient
+}
+
+// RedirectChain returns the URLs requested to obtain resp, from the original request
+// to the one that produced resp. The HTTP client follows redirects transparently:
+// the chain has a single URL when no redirect happened.
+func RedirectChain(resp *http.Response) []*url.URL {
+	var chain []*url.URL
+	for req := responseRequest(resp); req != nil; req = responseRequest(req.Response) {
+		chain = append([]*url.URL{req.URL}, chain...)
+	}
+	return chain
+}
+
+// responseRequest returns the request of a response, nil for a nil response
+func responseRequest(resp *http.Response) *http.Request {
+	if resp == nil {
+		return nil
+	}
+	return resp.Request
+}
+
+// CanonicalDomain reports the domain the company lookup was redirected to, for
+// instance newdomain.com when olddomain.com answered with a 301 redirect. ok is false
+// when the lookup was not redirected to another company.
+func (r FetchCompanyResponse) CanonicalDomain() (domain string, ok bool) {
+	chain := RedirectChain(r.HTTPResponse)
+	if len(chain) < 2 {
+		return "", false
+	}
+	requested, requestedOK := companyDomainFromPath(chain[0])
+	final, finalOK := companyDomainFromPath(chain[len(chain)-1])
+	if !requestedOK || !finalOK || strings.EqualFold(requested, final) {
+		return "", false
+	}
+	return final, true
+}
+
+// companyDomainFromPath extracts the domain of a /v2/companies/{domain} URL
+func companyDomainFromPath(u *url.URL) (string, bool) {
+	const prefix = "/v2/companies/"
+	path := u.EscapedPath()
+	i := strings.Index(path, prefix)
+	if i < 0 {
+		return "", false
+	}
+	escaped := path[i+len(prefix):]
+	if escaped == "" || strings.Contains(escaped, "/") {
+		return "", false
+	}
+	domain, err := url.PathUnescape(escaped)
+	if err != nil {
+		return "", false
+	}
+	return domain, true
+}
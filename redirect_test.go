@@ -0,0 +1,110 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCanonicalDomain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/companies/olddomain.com":
+			http.Redirect(w, r, "/v2/companies/intermediate.com", http.StatusMovedPermanently)
+		case "/v2/companies/intermediate.com":
+			http.Redirect(w, r, "/v2/companies/newdomain.com?simplified=true", http.StatusMovedPermanently)
+		case "/v2/companies/newdomain.com", "/v2/companies/apple.com":
+			if r.Header.Get("Authorization") != "Basic test-api-key" {
+				t.Errorf("Expected the redirected request to be authenticated")
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"about":{"name":"New Domain"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	response, err := client.FetchCompany(context.Background(), "olddomain.com", nil)
+	if err != nil {
+		t.Fatalf("FetchCompany returned error: %v", err)
+	}
+	if response.JSON200 == nil {
+		t.Fatalf("Expected the canonical company, got status %d", response.StatusCode())
+	}
+	if domain, ok := response.CanonicalDomain(); !ok || domain != "newdomain.com" {
+		t.Errorf("Expected canonical domain newdomain.com, got %q, %v", domain, ok)
+	}
+
+	chain := RedirectChain(response.HTTPResponse)
+	if len(chain) != 3 || chain[0].Path != "/v2/companies/olddomain.com" || chain[2].Path != "/v2/companies/newdomain.com" {
+		t.Errorf("Unexpected redirect chain: %v", chain)
+	}
+
+	response, err = client.FetchCompany(context.Background(), "apple.com", nil)
+	if err != nil {
+		t.Fatalf("FetchCompany returned error: %v", err)
+	}
+	if domain, ok := response.CanonicalDomain(); ok {
+		t.Errorf("Expected no canonical domain without redirect, got %q", domain)
+	}
+}
+
+func TestWithMaxRedirects(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// A redirect loop between two domains
+		if r.URL.Path == "/v2/companies/a.com" {
+			http.Redirect(w, r, "/v2/companies/b.com", http.StatusMovedPermanently)
+			return
+		}
+		http.Redirect(w, r, "/v2/companies/a.com", http.StatusMovedPermanently)
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL), WithMaxRedirects(3))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+	if _, err := client.FetchCompany(context.Background(), "a.com", nil); !errors.Is(err, ErrTooManyRedirects) {
+		t.Fatalf("Expected ErrTooManyRedirects, got %v", err)
+	}
+	if requests != 4 {
+		t.Errorf("Expected the original request and 3 redirects, got %d requests", requests)
+	}
+
+	requests = 0
+	client, err = ApiClient("test-api-key", WithCustomBaseURL(server.URL), WithNoRedirects())
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+	response, err := client.FetchCompany(context.Background(), "a.com", nil)
+	if err != nil {
+		t.Fatalf("FetchCompany returned error: %v", err)
+	}
+	if response.StatusCode() != http.StatusMovedPermanently || response.HTTPResponse.Header.Get("Location") != "/v2/companies/b.com" {
+		t.Errorf("Expected the redirect response, got status %d", response.StatusCode())
+	}
+	if requests != 1 {
+		t.Errorf("Expected a single request, got %d", requests)
+	}
+}
+
+func TestWithMaxRedirectsCustomHTTPClient(t *testing.T) {
+	httpClient := &http.Client{}
+	client := NewBaseClient("test-api-key", WithCustomHTTPClient(httpClient), WithNoRedirects())
+	if httpClient.CheckRedirect != nil {
+		t.Error("Expected the custom HTTP client to be left untouched")
+	}
+	if client.HTTPClient().CheckRedirect == nil {
+		t.Error("Expected the client to use the redirect policy")
+	}
+}
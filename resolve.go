@@ -0,0 +1,149 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Company is the company model returned by the API
+type Company = CompanyV2
+
+// ErrUnrecognizedIdentifier is returned by ResolveCompany for identifiers that are
+// neither a domain, an email address nor a social profile URL
+var ErrUnrecognizedIdentifier = errors.New("unrecognized company identifier")
+
+// ErrCompanyNotFound is matched by the errors of the lookups of a single company
+// (FetchCompanyResult, ResolveCompany...) when the company is not found
+var ErrCompanyNotFound = errors.New("company not found")
+
+// identifierKind is the type of identifier detected by classifyIdentifier
+type identifierKind int
+
+const (
+	domainIdentifier identifierKind = iota
+	emailIdentifier
+	socialIdentifier
+)
+
+// socialNetworks maps the hosts of social networks to the FetchCompanyBySocial
+// parameter of their profile URLs
+var socialNetworks = map[string]string{
+	"angel.co":       "angellist",
+	"dribbble.com":   "dribbble",
+	"facebook.com":   "facebook",
+	"github.com":     "github",
+	"instagram.com":  "instagram",
+	"linkedin.com":   "linkedin",
+	"pinterest.com":  "pinterest",
+	"snapchat.com":   "snapchat",
+	"soundcloud.com": "souncloud",
+	"tiktok.com":     "tiktok",
+	"twitter.com":    "twitter",
+	"wellfound.com":  "wellfound",
+	"x.com":          "twitter",
+	"youtube.com":    "youtube",
+}
+
+// newFetchCompanyBySocialParams sets the profile URL on the parameter of its network
+func newFetchCompanyBySocialParams(network, profileURL string) *FetchCompanyBySocialParams {
+	params := &FetchCompanyBySocialParams{}
+	switch network {
+	case "angellist":
+		params.Angellist = &profileURL
+	case "dribbble":
+		params.Dribbble = &profileURL
+	case "facebook":
+		params.Facebook = &profileURL
+	case "github":
+		params.Github = &profileURL
+	case "instagram":
+		params.Instagram = &profileURL
+	case "linkedin":
+		params.Linkedin = &profileURL
+	case "pinterest":
+		params.Pinterest = &profileURL
+	case "snapchat":
+		params.Snapchat = &profileURL
+	case "souncloud":
+		params.Souncloud = &profileURL
+	case "tiktok":
+		params.Tiktok = &profileURL
+	case "twitter":
+		params.Twitter = &profileURL
+	case "wellfound":
+		params.Wellfound = &profileURL
+	case "youtube":
+		params.Youtube = &profileURL
+	}
+	return params
+}
+
+// ResolveCompany fetches a company from a domain ("apple.com", "https://www.apple.com"),
+// an email address ("jane@apple.com") or a social profile URL
+// ("https://linkedin.com/company/apple"), dispatching to FetchCompany,
+// FetchCompanyByEmail or FetchCompanyBySocial. The bare domain of a social network
+// ("linkedin.com") resolves the network itself as a company.
+func (c *CompaniesAPIClient) ResolveCompany(ctx context.Context, identifier string) (*Company, error) {
+	kind, value, err := classifyIdentifier(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case emailIdentifier:
+		return c.FetchCompanyByEmailResult(ctx, &FetchCompanyByEmailParams{Email: value})
+	case socialIdentifier:
+		network, _ := socialNetwork(value)
+		company, err := c.FetchCompanyBySocialResult(ctx, newFetchCompanyBySocialParams(network, value))
+		if errors.Is(err, ErrCompanyNotFound) {
+			return nil, fmt.Errorf("%w for %s", err, value)
+		}
+		return company, err
+	default:
+		return c.FetchCompanyResult(ctx, value, nil)
+	}
+}
+
+// classifyIdentifier detects the type of a company identifier and normalizes it: the
+// email address, the social profile URL or the domain normalized like NormalizeDomains
+// does
+func classifyIdentifier(identifier string) (identifierKind, string, error) {
+	identifier = strings.TrimSpace(identifier)
+	if identifier == "" {
+		return 0, "", ErrUnrecognizedIdentifier
+	}
+
+	if isEmailAddress(identifier) {
+		if _, ok := normalizeDomain(identifier); !ok || ValidateEmail(identifier) != nil {
+			return 0, "", fmt.Errorf("%w: %q", ErrUnrecognizedIdentifier, identifier)
+		}
+		return emailIdentifier, identifier, nil
+	}
+
+	parsed, domain, ok := parseDomainURL(identifier)
+	if !ok {
+		return 0, "", fmt.Errorf("%w: %q", ErrUnrecognizedIdentifier, identifier)
+	}
+	profile := parsed.String()
+	if _, ok := socialNetwork(profile); ok && strings.Trim(parsed.Path, "/") != "" {
+		return socialIdentifier, profile, nil
+	}
+	return domainIdentifier, domain, nil
+}
+
+// socialNetwork returns the social network of a profile URL
+func socialNetwork(rawURL string) (string, bool) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", false
+	}
+	host := strings.ToLower(parsed.Hostname())
+	for _, prefix := range []string{"www.", "m.", "mobile."} {
+		host = strings.TrimPrefix(host, prefix)
+	}
+	network, ok := socialNetworks[host]
+	return network, ok
+}
@@ -0,0 +1,322 @@
+package thecompaniesapi
+
+import (
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRetryBaseDelay is the initial delay of the default exponential backoff
+const DefaultRetryBaseDelay = 500 * time.Millisecond
+
+// BackoffStrategy computes how long to wait before retrying a request.
+//
+// attempt is the number of the retry about to be made (1 for the first retry) and
+// resp is the response that triggered it, nil when the attempt failed with a
+// connection error (see WithRetryOnConnectionErrors).
+type BackoffStrategy interface {
+	NextDelay(attempt int, resp *http.Response) time.Duration
+}
+
+// ConstantBackoff waits the same delay before every retry
+type ConstantBackoff struct {
+	Delay time.Duration
+}
+
+// NextDelay implements BackoffStrategy
+func (b ConstantBackoff) NextDelay(attempt int, resp *http.Response) time.Duration {
+	return b.Delay
+}
+
+// ExponentialBackoff doubles the delay after every retry: Base, 2*Base, 4*Base...
+type ExponentialBackoff struct {
+	Base time.Duration
+}
+
+// NextDelay implements BackoffStrategy
+func (b ExponentialBackoff) NextDelay(attempt int, resp *http.Response) time.Duration {
+	return scaleDelay(b.Base, math.Pow(2, float64(attempt-1)))
+}
+
+// DecorrelatedJitterBackoff spreads concurrent retries by picking a random delay
+// between Base and three times the previous upper bound, capped to Cap when set.
+// The previous delay is derived from the attempt so the strategy stays stateless
+// and can be shared across requests.
+type DecorrelatedJitterBackoff struct {
+	Base time.Duration
+	Cap  time.Duration
+}
+
+// NextDelay implements BackoffStrategy
+func (b DecorrelatedJitterBackoff) NextDelay(attempt int, resp *http.Response) time.Duration {
+	upper := scaleDelay(b.Base, math.Pow(3, float64(attempt)))
+	if b.Cap > 0 && upper > b.Cap {
+		upper = b.Cap
+	}
+	if upper <= b.Base {
+		return upper
+	}
+	return b.Base + time.Duration(rand.Int63n(int64(upper-b.Base)))
+}
+
+// scaleDelay multiplies a delay without overflowing
+func scaleDelay(delay time.Duration, factor float64) time.Duration {
+	scaled := float64(delay) * factor
+	if scaled >= math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(scaled)
+}
+
+// WithRetry retries requests answered with 429 Too Many Requests, 502 Bad Gateway,
+// 503 Service Unavailable or 504 Gateway Timeout, making at most maxAttempts
+// attempts in total. Delays between attempts follow the backoff strategy, an
+// ExponentialBackoff starting at DefaultRetryBaseDelay unless WithBackoffStrategy is
+// used, or the longer maintenance backoff (see WithMaintenanceBackoff) when the API
+// answers with its maintenance page. When the response carries a Retry-After header,
+// either in delta-seconds or as an HTTP-date, the client waits for the larger of the
+// backoff delay and the server hint. Requests whose body cannot be replayed are not retried.
+func WithRetry(maxAttempts int) BaseClientOption {
+	return func(c *BaseClient) {
+		c.retryPolicy().maxAttempts = maxAttempts
+	}
+}
+
+// WithBackoffStrategy sets the strategy computing the delay between retries
+func WithBackoffStrategy(strategy BackoffStrategy) BaseClientOption {
+	return func(c *BaseClient) {
+		c.retryPolicy().backoff = strategy
+	}
+}
+
+// WithMaxRetryDelay caps the delay between two attempts to maxDelay, whatever the
+// backoff strategy and the attempt number, to keep retries within a latency budget.
+// Requests whose Retry-After header asks to wait longer than maxDelay are not retried:
+// their response is returned instead of calling the API before the requested time.
+func WithMaxRetryDelay(maxDelay time.Duration) BaseClientOption {
+	return func(c *BaseClient) {
+		c.retryPolicy().maxDelay = maxDelay
+	}
+}
+
+// WithRetryOnConnectionErrors controls whether the attempts failing before any
+// response is received, because the connection was refused, reset or dropped, the DNS
+// lookup temporarily failed or the network timed out, are retried like the retryable
+// statuses of WithRetry. Such a request may have reached the API, so enabling it
+// retries non-idempotent calls which might have been processed. Errors caused by the
+// request context, like its cancellation or deadline, are never retried.
+func WithRetryOnConnectionErrors(enabled bool) BaseClientOption {
+	return func(c *BaseClient) {
+		c.retryPolicy().retryConnectionErrors = enabled
+	}
+}
+
+// WithRetryBudget caps the retries made by all the requests of the client to
+// maxRetries per window, protecting the API from retry storms during an outage. The
+// budget is a token bucket refilled continuously: once exhausted, failed requests
+// return their response without being retried until tokens are available again.
+func WithRetryBudget(maxRetries int, window time.Duration) BaseClientOption {
+	return func(c *BaseClient) {
+		c.retryPolicy().budget = newRetryBudget(maxRetries, window)
+	}
+}
+
+// retryPolicy defines when and how failed requests are retried
+type retryPolicy struct {
+	maxAttempts        int
+	backoff            BackoffStrategy
+	maintenanceBackoff BackoffStrategy
+	maxDelay           time.Duration
+	budget             *retryBudget
+	// retryConnectionErrors retries the transport errors of isConnectionError
+	retryConnectionErrors bool
+}
+
+// retryBudget is a token bucket shared by the requests of a client, a token being
+// spent for each retry
+type retryBudget struct {
+	mu       sync.Mutex
+	capacity float64
+	tokens   float64
+	// refill is the number of tokens regained per second
+	refill  float64
+	updated time.Time
+	now     func() time.Time
+}
+
+func newRetryBudget(maxRetries int, window time.Duration) *retryBudget {
+	budget := &retryBudget{
+		capacity: float64(maxRetries),
+		tokens:   float64(maxRetries),
+		now:      time.Now,
+	}
+	if window > 0 {
+		budget.refill = float64(maxRetries) / window.Seconds()
+	}
+	budget.updated = budget.now()
+	return budget
+}
+
+// take spends a token, reporting false when the budget is exhausted
+func (b *retryBudget) take() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.tokens = math.Min(b.capacity, b.tokens+now.Sub(b.updated).Seconds()*b.refill)
+	b.updated = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// retryPolicy returns the client retry policy, creating it with defaults if needed
+func (c *BaseClient) retryPolicy() *retryPolicy {
+	if c.retry == nil {
+		c.retry = &retryPolicy{
+			maxAttempts:        1,
+			backoff:            ExponentialBackoff{Base: DefaultRetryBaseDelay},
+			maintenanceBackoff: ConstantBackoff{Delay: DefaultMaintenanceRetryDelay},
+		}
+	}
+	return c.retry
+}
+
+// isRetryableStatus reports whether a response status is worth retrying
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isConnectionError reports whether a transport error is a network failure worth
+// retrying: a dropped or refused connection, a temporary DNS failure or a timeout
+func isConnectionError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// capDelay bounds a retry delay to the maximum delay of the policy, if any
+func (p *retryPolicy) capDelay(delay time.Duration) time.Duration {
+	if p.maxDelay > 0 && delay > p.maxDelay {
+		return p.maxDelay
+	}
+	return delay
+}
+
+// retryDelay returns the delay before retrying a response: the larger of the backoff
+// delay and the wait requested by its Retry-After header
+func retryDelay(backoff BackoffStrategy, attempt int, resp *http.Response, now time.Time) time.Duration {
+	delay := backoff.NextDelay(attempt, resp)
+	if hint, ok := parseRetryAfter(resp.Header.Get("Retry-After"), now); ok && hint > delay {
+		delay = hint
+	}
+	return delay
+}
+
+// parseRetryAfter parses a Retry-After header value, either a number of seconds or an
+// HTTP-date. A date in the past results in a zero wait.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return scaleDelay(time.Second, float64(seconds)), true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if wait := date.Sub(now); wait > 0 {
+		return wait, true
+	}
+	return 0, true
+}
+
+// do sends the request, retrying it according to the policy
+func (p *retryPolicy) do(req *http.Request, send roundTripFunc) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 {
+			attemptReq = req.Clone(ctx)
+			if req.Body != nil && req.Body != http.NoBody {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attemptReq.Body = body
+			}
+		}
+
+		resp, err := send(attemptReq)
+		if attempt >= p.maxAttempts {
+			return resp, err
+		}
+		if err != nil {
+			if !p.retryConnectionErrors || ctx.Err() != nil || !isConnectionError(err) {
+				return nil, err
+			}
+		} else if !isRetryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return resp, err
+		}
+		if resp != nil {
+			if hint, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok && p.maxDelay > 0 && hint > p.maxDelay {
+				return resp, nil
+			}
+		}
+		if p.budget != nil && !p.budget.take() {
+			return resp, err
+		}
+
+		var delay time.Duration
+		if resp == nil {
+			delay = p.capDelay(p.backoff.NextDelay(attempt, nil))
+		} else {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			backoff := p.backoff
+			if isMaintenanceResponse(resp.StatusCode, body) {
+				backoff = p.maintenanceBackoff
+			}
+			delay = p.capDelay(retryDelay(backoff, attempt, resp, time.Now()))
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// recordingBackoff records the retries it is asked about and never waits
+type recordingBackoff struct {
+	attempts []int
+	statuses []int
+}
+
+func (b *recordingBackoff) NextDelay(attempt int, resp *http.Response) time.Duration {
+	b.attempts = append(b.attempts, attempt)
+	b.statuses = append(b.statuses, resp.StatusCode)
+	return 0
+}
+
+func TestWithBackoffStrategy(t *testing.T) {
+	statuses := []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK}
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.WriteHeader(statuses[len(bodies)-1])
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	backoff := &recordingBackoff{}
+	client := NewBaseClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithRetry(5),
+		WithBackoffStrategy(backoff),
+	)
+
+	if _, err := client.MakeRequest(context.Background(), "POST", "/v2/companies", map[string]string{"search": "saas"}); err != nil {
+		t.Fatalf("MakeRequest failed: %v", err)
+	}
+
+	if len(bodies) != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", len(bodies))
+	}
+	for _, body := range bodies {
+		if body != `{"search":"saas"}` {
+			t.Errorf("Expected the body to be replayed, got %q", body)
+		}
+	}
+	if len(backoff.attempts) != 2 || backoff.attempts[0] != 1 || backoff.attempts[1] != 2 {
+		t.Errorf("Expected delays requested for attempts [1 2], got %v", backoff.attempts)
+	}
+	if backoff.statuses[0] != http.StatusServiceUnavailable || backoff.statuses[1] != http.StatusTooManyRequests {
+		t.Errorf("Expected the failed responses to be passed to the strategy, got %v", backoff.statuses)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewBaseClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithRetry(3),
+		WithBackoffStrategy(ConstantBackoff{}),
+	)
+	if _, err := client.MakeRequest(context.Background(), "GET", "/", nil); err == nil {
+		t.Fatal("Expected an error once retries are exhausted")
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestWithRetryBudget(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewBaseClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithRetry(5),
+		WithBackoffStrategy(ConstantBackoff{}),
+		WithRetryBudget(3, time.Minute),
+	)
+	now := time.Now()
+	client.retry.budget.now = func() time.Time { return now }
+	client.retry.budget.updated = now
+
+	// The first request spends the whole budget: 1 attempt + 3 retries
+	if _, err := client.MakeRequest(context.Background(), "GET", "/", nil); err == nil {
+		t.Fatal("Expected an error")
+	}
+	if attempts != 4 {
+		t.Fatalf("Expected 4 attempts, got %d", attempts)
+	}
+
+	// Requests fail fast while the budget is exhausted
+	attempts = 0
+	if _, err := client.MakeRequest(context.Background(), "GET", "/", nil); err == nil {
+		t.Fatal("Expected an error")
+	}
+	if attempts != 1 {
+		t.Errorf("Expected no retry once the budget is depleted, got %d attempts", attempts)
+	}
+
+	// A third of the window refills one token
+	now = now.Add(20 * time.Second)
+	attempts = 0
+	client.MakeRequest(context.Background(), "GET", "/", nil)
+	if attempts != 2 {
+		t.Errorf("Expected a single retry after the refill, got %d attempts", attempts)
+	}
+}
+
+func TestBackoffStrategies(t *testing.T) {
+	if delay := (ConstantBackoff{Delay: time.Second}).NextDelay(4, nil); delay != time.Second {
+		t.Errorf("ConstantBackoff returned %v", delay)
+	}
+
+	exponential := ExponentialBackoff{Base: 100 * time.Millisecond}
+	for attempt, expected := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 4: 800 * time.Millisecond} {
+		if delay := exponential.NextDelay(attempt, nil); delay != expected {
+			t.Errorf("ExponentialBackoff attempt %d returned %v, expected %v", attempt, delay, expected)
+		}
+	}
+
+	jitter := DecorrelatedJitterBackoff{Base: 100 * time.Millisecond, Cap: time.Second}
+	for attempt := 1; attempt <= 10; attempt++ {
+		if delay := jitter.NextDelay(attempt, nil); delay < jitter.Base || delay > jitter.Cap {
+			t.Errorf("DecorrelatedJitterBackoff attempt %d returned %v outside [%v, %v]", attempt, delay, jitter.Base, jitter.Cap)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"120", 2 * time.Minute, true},
+		{" 0 ", 0, true},
+		{now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second, true},
+		{"Fri, 01 Mar 2024 11:59:00 GMT", 0, true},
+		{"", 0, false},
+		{"-5", 0, false},
+		{"soon", 0, false},
+	}
+	for _, c := range cases {
+		delay, ok := parseRetryAfter(c.value, now)
+		if delay != c.expected || ok != c.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; expected %v, %v", c.value, delay, ok, c.expected, c.ok)
+		}
+	}
+}
+
+func TestRetryDelayHonorsRetryAfter(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	backoff := ConstantBackoff{Delay: 5 * time.Second}
+	response := func(retryAfter string) *http.Response {
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {retryAfter}}}
+	}
+
+	// The larger of the backoff and the server hint is used
+	if delay := retryDelay(backoff, 1, response("10"), now); delay != 10*time.Second {
+		t.Errorf("Expected the delta-seconds hint, got %v", delay)
+	}
+	if delay := retryDelay(backoff, 1, response(now.Add(time.Minute).Format(http.TimeFormat)), now); delay != time.Minute {
+		t.Errorf("Expected the HTTP-date hint, got %v", delay)
+	}
+	if delay := retryDelay(backoff, 1, response("1"), now); delay != 5*time.Second {
+		t.Errorf("Expected the backoff delay over a shorter hint, got %v", delay)
+	}
+	if delay := retryDelay(backoff, 1, response(now.Add(-time.Minute).Format(http.TimeFormat)), now); delay != 5*time.Second {
+		t.Errorf("Expected the backoff delay for a past date, got %v", delay)
+	}
+}
+
+func TestWithMaxRetryDelay(t *testing.T) {
+	client := NewBaseClient("test-api-key", WithMaxRetryDelay(10*time.Second))
+	policy := client.retryPolicy()
+	for attempt := 1; attempt <= 100; attempt++ {
+		if delay := policy.capDelay(policy.backoff.NextDelay(attempt, nil)); delay > 10*time.Second || delay <= 0 {
+			t.Fatalf("Attempt %d returned %v, expected at most 10s", attempt, delay)
+		}
+	}
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if r.URL.Path == "/later" {
+			w.Header().Set("Retry-After", "3600")
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client = NewBaseClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithRetry(3),
+		WithBackoffStrategy(ConstantBackoff{Delay: time.Hour}),
+		WithMaxRetryDelay(time.Millisecond),
+	)
+	start := time.Now()
+	client.MakeRequest(context.Background(), "GET", "/now", nil)
+	if attempts != 3 || time.Since(start) > 5*time.Second {
+		t.Errorf("Expected 3 quick attempts, got %d in %v", attempts, time.Since(start))
+	}
+
+	// A server hint beyond the cap is honored by not retrying
+	attempts = 0
+	client.MakeRequest(context.Background(), "GET", "/later", nil)
+	if attempts != 1 {
+		t.Errorf("Expected a single attempt, got %d", attempts)
+	}
+}
+
+func TestWithRetryOnConnectionErrors(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			// Drop the connection without answering
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("Hijack failed: %v", err)
+				return
+			}
+			conn.Close()
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	client := NewBaseClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithRetry(3),
+		WithBackoffStrategy(ConstantBackoff{}),
+		WithRetryOnConnectionErrors(true),
+	)
+	body, err := client.MakeRequest(context.Background(), "POST", "/v2/actions", map[string]string{"job": "enrich"})
+	if err != nil {
+		t.Fatalf("MakeRequest failed: %v", err)
+	}
+	if attempts.Load() != 2 || string(body) != `{"job":"enrich"}` {
+		t.Errorf("Expected the body to be replayed on a second attempt, got %d attempts and %q", attempts.Load(), body)
+	}
+
+	// Transport errors are returned as is by default
+	attempts.Store(0)
+	client = NewBaseClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithRetry(3),
+		WithBackoffStrategy(ConstantBackoff{}),
+	)
+	if _, err := client.MakeRequest(context.Background(), "POST", "/v2/actions", nil); err == nil || attempts.Load() != 1 {
+		t.Errorf("Expected the dropped connection error after 1 attempt, got %v after %d", err, attempts.Load())
+	}
+}
+
+func TestIsConnectionError(t *testing.T) {
+	for _, test := range []struct {
+		err      error
+		expected bool
+	}{
+		{io.EOF, true},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, true},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "server misbehaving", IsTemporary: true}}, true},
+		{&net.DNSError{Err: "no such host", IsNotFound: true}, false},
+		{&url.Error{Op: "Post", URL: "https://api.thecompaniesapi.com", Err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}}, true},
+		{errors.New("x509: certificate signed by unknown authority"), false},
+	} {
+		if got := isConnectionError(test.err); got != test.expected {
+			t.Errorf("isConnectionError(%v) = %v, expected %v", test.err, got, test.expected)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// RequestOption overrides request-level settings of a scoped client returned by
+// CompaniesAPIClient.With
+type RequestOption func(req *http.Request)
+
+// WithRequestHeader sets a header on every request of the scoped client
+func WithRequestHeader(key, value string) RequestOption {
+	return func(req *http.Request) {
+		req.Header.Set(key, value)
+	}
+}
+
+// WithRequestVisitorID overrides the visitor ID configured with WithVisitorID
+func WithRequestVisitorID(visitorID string) RequestOption {
+	return WithRequestHeader("Tca-Visitor-Id", visitorID)
+}
+
+// WithRequestID sets the X-Request-Id header used to correlate requests across systems
+func WithRequestID(requestID string) RequestOption {
+	return WithRequestHeader("X-Request-Id", requestID)
+}
+
+// With returns a scoped client applying the request options to all of its calls,
+// including MakeRequestTyped. The scoped client shares the configuration, connection
+// pool and cached tokens of c, is cheap to create and leaves c unchanged:
+//
+//	scoped := client.With(thecompaniesapi.WithRequestID(requestID))
+//	company, err := scoped.FetchCompany(ctx, "apple.com", nil)
+func (c *CompaniesAPIClient) With(opts ...RequestOption) *CompaniesAPIClient {
+	editor := func(ctx context.Context, req *http.Request) error {
+		for _, opt := range opts {
+			opt(req)
+		}
+		return nil
+	}
+
+	scope := make([]RequestEditorFn, 0, len(c.scope)+1)
+	scope = append(scope, c.scope...)
+	scope = append(scope, editor)
+
+	// Mirror the generated client built by ApiClient, with the scoped request editors
+	generated := &Client{
+		Server:         strings.TrimRight(c.baseClient.BaseURL(), "/") + "/",
+		Client:         c.baseClient,
+		RequestEditors: scope,
+	}
+
+	return &CompaniesAPIClient{
+		ClientWithResponses: &ClientWithResponses{ClientInterface: generated},
+		baseClient:          c.baseClient,
+		scope:               scope,
+	}
+}
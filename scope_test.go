@@ -0,0 +1,60 @@
+package thecompaniesapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/thecompaniesapi/sdk-go"
+)
+
+func TestScopedClient(t *testing.T) {
+	var requests []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Header.Clone())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := thecompaniesapi.ApiClient("test-api-key",
+		thecompaniesapi.WithCustomBaseURL(server.URL),
+		thecompaniesapi.WithVisitorID("default-visitor"),
+	)
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	scoped := client.With(
+		thecompaniesapi.WithRequestVisitorID("scoped-visitor"),
+		thecompaniesapi.WithRequestID("req-123"),
+		thecompaniesapi.WithRequestHeader("X-Tenant", "acme"),
+	)
+	ctx := context.Background()
+
+	if _, err := scoped.FetchApiHealth(ctx); err != nil {
+		t.Fatalf("Scoped FetchApiHealth returned error: %v", err)
+	}
+	if _, err := thecompaniesapi.MakeRequestTyped[map[string]any](ctx, scoped, "GET", "/v2/preview", nil); err != nil {
+		t.Fatalf("Scoped MakeRequestTyped returned error: %v", err)
+	}
+	if _, err := client.FetchApiHealth(ctx); err != nil {
+		t.Fatalf("FetchApiHealth returned error: %v", err)
+	}
+
+	if len(requests) != 3 {
+		t.Fatalf("Expected 3 requests, got %d", len(requests))
+	}
+	for i, headers := range requests[:2] {
+		if headers.Get("Tca-Visitor-Id") != "scoped-visitor" || headers.Get("X-Request-Id") != "req-123" || headers.Get("X-Tenant") != "acme" {
+			t.Errorf("Scoped request %d is missing overrides: %v", i, headers)
+		}
+		if headers.Get("Authorization") != "Basic test-api-key" {
+			t.Errorf("Scoped request %d is not authenticated: %v", i, headers)
+		}
+	}
+	if headers := requests[2]; headers.Get("Tca-Visitor-Id") != "default-visitor" || headers.Get("X-Request-Id") != "" || headers.Get("X-Tenant") != "" {
+		t.Errorf("Unscoped request carries scoped overrides: %v", headers)
+	}
+}
@@ -0,0 +1,216 @@
+package thecompaniesapi
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Page size bounds of the search endpoints
+const (
+	MinSearchSize = 1
+	MaxSearchSize = 100
+)
+
+// ErrInvalidSearchParams is matched by the errors returned by SearchCompaniesParams.Validate
+var ErrInvalidSearchParams = errors.New("invalid search parameters")
+
+// Validate checks the parameters against the bounds enforced by the API so that mistakes
+// surface client-side instead of as a 400 response: page must be at least 1, size must
+// be between MinSearchSize and MaxSearchSize and search fields must be known.
+func (p *SearchCompaniesParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.Page != nil && *p.Page < 1 {
+		return fmt.Errorf("%w: page must be at least 1, got %v", ErrInvalidSearchParams, *p.Page)
+	}
+	if p.Size != nil && (*p.Size < MinSearchSize || *p.Size > MaxSearchSize) {
+		return fmt.Errorf("%w: size must be between %d and %d, got %v", ErrInvalidSearchParams, MinSearchSize, MaxSearchSize, *p.Size)
+	}
+	if p.SearchFields != nil {
+		for _, field := range *p.SearchFields {
+			switch field {
+			case SearchCompaniesParamsSearchFieldsAboutName, SearchCompaniesParamsSearchFieldsDomainDomain:
+			default:
+				return fmt.Errorf("%w: unknown search field %q", ErrInvalidSearchParams, field)
+			}
+		}
+	}
+	return nil
+}
+
+// HashSearchBody returns a stable hash of a search body, usable as a cache key or to
+// correlate logs: the hex encoded SHA-256 of its canonical JSON, in which object keys
+// are sorted and unset fields omitted, so that logically equal bodies hash identically
+// whatever the order their fields were decoded or set in.
+func HashSearchBody(body SearchCompaniesPostJSONRequestBody) string {
+	sum := sha256.Sum256(canonicalJSON(body))
+	return hex.EncodeToString(sum[:])
+}
+
+// canonicalJSON encodes a value as JSON with sorted object keys
+func canonicalJSON(value any) []byte {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	// Decoding to generic values sorts the keys of objects, including those of maps
+	// and of union types serialized from raw JSON, once encoded again
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var generic any
+	if err := decoder.Decode(&generic); err != nil {
+		return encoded
+	}
+	canonical, err := json.Marshal(generic)
+	if err != nil {
+		return encoded
+	}
+	return canonical
+}
+
+// ToPostBody converts the parameters of SearchCompanies to the equivalent body of
+// SearchCompaniesPost, to run the same query on the POST endpoint, e.g. once its
+// conditions get too large for a URL. Pointer fields of identical types, like Query,
+// are shared with p.
+func (p *SearchCompaniesParams) ToPostBody() SearchCompaniesPostJSONRequestBody {
+	if p == nil {
+		return SearchCompaniesPostJSONRequestBody{}
+	}
+	body := SearchCompaniesPostJSONRequestBody{
+		ActionId:          p.ActionId,
+		DomainsToExclude:  p.DomainsToExclude,
+		LinkedinToExclude: p.LinkedinToExclude,
+		Page:              p.Page,
+		Query:             p.Query,
+		Search:            p.Search,
+		Simplified:        p.Simplified,
+		Size:              p.Size,
+	}
+	if p.SearchFields != nil {
+		fields := make([]SearchCompaniesPostJSONBodySearchFields, len(*p.SearchFields))
+		for i, field := range *p.SearchFields {
+			fields[i] = SearchCompaniesPostJSONBodySearchFields(field)
+		}
+		body.SearchFields = &fields
+	}
+	if p.SortFields != nil {
+		sortFields := make([]struct {
+			Key     SearchCompaniesPostJSONBodySortFieldsKey      `json:"key"`
+			Missing *SearchCompaniesPostJSONBodySortFieldsMissing `json:"missing,omitempty"`
+			Order   SearchCompaniesPostJSONBodySortFieldsOrder    `json:"order"`
+		}, len(*p.SortFields))
+		for i, field := range *p.SortFields {
+			sortFields[i].Key = SearchCompaniesPostJSONBodySortFieldsKey(field.Key)
+			sortFields[i].Order = SearchCompaniesPostJSONBodySortFieldsOrder(field.Order)
+			if field.Missing != nil {
+				missing := SearchCompaniesPostJSONBodySortFieldsMissing(*field.Missing)
+				sortFields[i].Missing = &missing
+			}
+		}
+		body.SortFields = &sortFields
+	}
+	if p.SortKey != nil {
+		sortKey := SearchCompaniesPostJSONBodySortKey(*p.SortKey)
+		body.SortKey = &sortKey
+	}
+	if p.SortOrder != nil {
+		sortOrder := SearchCompaniesPostJSONBodySortOrder(*p.SortOrder)
+		body.SortOrder = &sortOrder
+	}
+	return body
+}
+
+// ToParams converts the body of SearchCompaniesPost to the equivalent parameters of
+// SearchCompanies, the reverse of SearchCompaniesParams.ToPostBody. Pointer fields of
+// identical types, like Query, are shared with b.
+func (b SearchCompaniesPostJSONRequestBody) ToParams() *SearchCompaniesParams {
+	params := &SearchCompaniesParams{
+		ActionId:          b.ActionId,
+		DomainsToExclude:  b.DomainsToExclude,
+		LinkedinToExclude: b.LinkedinToExclude,
+		Page:              b.Page,
+		Query:             b.Query,
+		Search:            b.Search,
+		Simplified:        b.Simplified,
+		Size:              b.Size,
+	}
+	if b.SearchFields != nil {
+		fields := make([]SearchCompaniesParamsSearchFields, len(*b.SearchFields))
+		for i, field := range *b.SearchFields {
+			fields[i] = SearchCompaniesParamsSearchFields(field)
+		}
+		params.SearchFields = &fields
+	}
+	if b.SortFields != nil {
+		sortFields := make([]struct {
+			Key     SearchCompaniesParamsSortFieldsKey      `json:"key"`
+			Missing *SearchCompaniesParamsSortFieldsMissing `json:"missing,omitempty"`
+			Order   SearchCompaniesParamsSortFieldsOrder    `json:"order"`
+		}, len(*b.SortFields))
+		for i, field := range *b.SortFields {
+			sortFields[i].Key = SearchCompaniesParamsSortFieldsKey(field.Key)
+			sortFields[i].Order = SearchCompaniesParamsSortFieldsOrder(field.Order)
+			if field.Missing != nil {
+				missing := SearchCompaniesParamsSortFieldsMissing(*field.Missing)
+				sortFields[i].Missing = &missing
+			}
+		}
+		params.SortFields = &sortFields
+	}
+	if b.SortKey != nil {
+		sortKey := SearchCompaniesParamsSortKey(*b.SortKey)
+		params.SortKey = &sortKey
+	}
+	if b.SortOrder != nil {
+		sortOrder := SearchCompaniesParamsSortOrder(*b.SortOrder)
+		params.SortOrder = &sortOrder
+	}
+	return params
+}
+
+// ToCountParams converts the filters of the search to the parameters of CountCompanies,
+// to count the companies the search matches: the action, query, search and search
+// fields. Pagination, sorting and the exclusions of DomainsToExclude and
+// LinkedinToExclude have no count equivalent and are dropped. Pointer fields of
+// identical types, like Query, are shared with p.
+func (p *SearchCompaniesParams) ToCountParams() *CountCompaniesParams {
+	if p == nil {
+		return &CountCompaniesParams{}
+	}
+	params := &CountCompaniesParams{
+		ActionId: p.ActionId,
+		Query:    p.Query,
+		Search:   p.Search,
+	}
+	if p.SearchFields != nil {
+		fields := make([]CountCompaniesParamsSearchFields, len(*p.SearchFields))
+		for i, field := range *p.SearchFields {
+			fields[i] = CountCompaniesParamsSearchFields(field)
+		}
+		params.SearchFields = &fields
+	}
+	return params
+}
+
+// ToCountBody is the SearchCompaniesPost equivalent of SearchCompaniesParams.ToCountParams,
+// converting the filters of the search to the body of CountCompaniesPost
+func (b SearchCompaniesPostJSONRequestBody) ToCountBody() CountCompaniesPostJSONRequestBody {
+	body := CountCompaniesPostJSONRequestBody{
+		ActionId: b.ActionId,
+		Query:    b.Query,
+		Search:   b.Search,
+	}
+	if b.SearchFields != nil {
+		fields := make([]CountCompaniesPostJSONBodySearchFields, len(*b.SearchFields))
+		for i, field := range *b.SearchFields {
+			fields[i] = CountCompaniesPostJSONBodySearchFields(field)
+		}
+		body.SearchFields = &fields
+	}
+	return body
+}
@@ -0,0 +1,113 @@
+package thecompaniesapi
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSearchCompaniesParamsValidate(t *testing.T) {
+	page := func(v float32) *float32 { return &v }
+	fields := func(v ...SearchCompaniesParamsSearchFields) *[]SearchCompaniesParamsSearchFields { return &v }
+
+	valid := []*SearchCompaniesParams{
+		nil,
+		{},
+		{Page: page(1), Size: page(MaxSearchSize)},
+		{SearchFields: fields(SearchCompaniesParamsSearchFieldsAboutName, SearchCompaniesParamsSearchFieldsDomainDomain)},
+	}
+	for i, params := range valid {
+		if err := params.Validate(); err != nil {
+			t.Errorf("Expected params %d to be valid, got %v", i, err)
+		}
+	}
+
+	invalid := map[string]*SearchCompaniesParams{
+		"page 0":        {Page: page(0)},
+		"size 0":        {Size: page(0)},
+		"size 101":      {Size: page(MaxSearchSize + 1)},
+		"unknown field": {SearchFields: fields("about.description")},
+	}
+	for name, params := range invalid {
+		if err := params.Validate(); !errors.Is(err, ErrInvalidSearchParams) {
+			t.Errorf("%s: expected ErrInvalidSearchParams, got %v", name, err)
+		}
+	}
+}
+
+func TestHashSearchBody(t *testing.T) {
+	decode := func(raw string) SearchCompaniesPostJSONRequestBody {
+		var body SearchCompaniesPostJSONRequestBody
+		if err := json.Unmarshal([]byte(raw), &body); err != nil {
+			t.Fatalf("Failed to decode body: %v", err)
+		}
+		return body
+	}
+
+	first := decode(`{"search":"saas","page":2,"query":[{"attribute":"about.industries","operator":"and","sign":"equals","values":["software"]}]}`)
+	second := decode(`{"query":[{"values":["software"],"sign":"equals","operator":"and","attribute":"about.industries"}],"page":2,"search":"saas"}`)
+	if HashSearchBody(first) != HashSearchBody(second) {
+		t.Error("Expected bodies with different field order to hash identically")
+	}
+	if hash := HashSearchBody(first); len(hash) != 64 || hash != HashSearchBody(first) {
+		t.Errorf("Expected a stable hex SHA-256, got %q", hash)
+	}
+
+	third := decode(`{"search":"saas","page":3}`)
+	if HashSearchBody(first) == HashSearchBody(third) {
+		t.Error("Expected different bodies to hash differently")
+	}
+}
+
+func TestSearchParamsPostBodyRoundTrip(t *testing.T) {
+	raw := `{"actionId":7,"domainsToExclude":"apple.com","linkedinToExclude":"apple","page":2,"query":[{"attribute":"about.industries","operator":"and","sign":"equals","values":["software"]}],"search":"saas","searchFields":["about.name"],"simplified":true,"size":25,"sortFields":[{"key":"about.name","missing":"_last","order":"asc"}],"sortKey":"about.name","sortOrder":"desc"}`
+	var params SearchCompaniesParams
+	if err := json.Unmarshal([]byte(raw), &params); err != nil {
+		t.Fatalf("Failed to decode params: %v", err)
+	}
+	// Every field is set so that a field added to the API without being mapped fails the test
+	fields := reflect.ValueOf(params)
+	for i := 0; i < fields.NumField(); i++ {
+		if fields.Field(i).IsNil() {
+			t.Fatalf("Expected the fixture to set %s", fields.Type().Field(i).Name)
+		}
+	}
+
+	body := params.ToPostBody()
+	encodedParams, _ := json.Marshal(params)
+	encodedBody, _ := json.Marshal(body)
+	if string(encodedBody) != string(encodedParams) {
+		t.Errorf("Expected the body to match the params:\n%s\n%s", encodedBody, encodedParams)
+	}
+	if back := body.ToParams(); !reflect.DeepEqual(*back, params) {
+		t.Errorf("Expected the round trip to restore the params, got %+v", *back)
+	}
+
+	if (*SearchCompaniesParams)(nil).ToPostBody().Query != nil {
+		t.Error("Expected nil params to convert to an empty body")
+	}
+}
+
+func TestSearchParamsToCount(t *testing.T) {
+	raw := `{"actionId":7,"domainsToExclude":"apple.com","page":2,"query":[{"attribute":"about.industries","operator":"and","sign":"equals","values":["software"]}],"search":"saas","searchFields":["about.name"],"size":25,"sortKey":"about.name"}`
+	var params SearchCompaniesParams
+	if err := json.Unmarshal([]byte(raw), &params); err != nil {
+		t.Fatalf("Failed to decode params: %v", err)
+	}
+
+	// Only the filters are copied, pagination and sorting do not apply to a count
+	expected := `{"actionId":7,"query":[{"attribute":"about.industries","operator":"and","sign":"equals","values":["software"]}],"search":"saas","searchFields":["about.name"]}`
+	encodedParams, _ := json.Marshal(params.ToCountParams())
+	if string(encodedParams) != expected {
+		t.Errorf("Unexpected count params:\n%s\n%s", encodedParams, expected)
+	}
+	encodedBody, _ := json.Marshal(params.ToPostBody().ToCountBody())
+	if string(encodedBody) != expected {
+		t.Errorf("Unexpected count body:\n%s\n%s", encodedBody, expected)
+	}
+
+	if (*SearchCompaniesParams)(nil).ToCountParams().Query != nil {
+		t.Error("Expected nil params to convert to empty count params")
+	}
+}
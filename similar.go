@@ -0,0 +1,156 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidSimilarityWeight is returned by SearchSimilarCompaniesWeighted for seeds
+// whose weights cannot be averaged
+var ErrInvalidSimilarityWeight = errors.New("invalid similarity weight")
+
+// SimilaritySeed is a seed company of SearchSimilarCompaniesWeighted
+type SimilaritySeed struct {
+	Domain string
+	// Weight is the importance of the seed relative to the others, 1 when zero
+	Weight float64
+}
+
+// WeightedSimilarCompany is a company found by SearchSimilarCompaniesWeighted
+type WeightedSimilarCompany struct {
+	Company Company
+	// Score is the weighted average of the similarity of the company to each seed
+	Score float64
+}
+
+// SearchSimilarCompaniesWeighted finds the companies similar to several seeds of
+// different importance. SearchSimilarCompanies accepts several domains but weighs them
+// equally, so a search is run per seed and the results are merged: the score of a
+// company is the weighted average of its similarity to each seed, counting 0 for the
+// seeds that did not return it. Companies are sorted by descending score and the seeds
+// are excluded from the results.
+//
+// params (Domains excepted) apply to every per-seed search. The first error cancels the
+// other searches and is returned. Negative or non-finite weights, and seeds without
+// total weight, return ErrInvalidSimilarityWeight before any search.
+func (c *CompaniesAPIClient) SearchSimilarCompaniesWeighted(ctx context.Context, seeds []SimilaritySeed, params *SearchSimilarCompaniesParams) ([]WeightedSimilarCompany, error) {
+	if err := validateSimilaritySeeds(seeds); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg        sync.WaitGroup
+		once      sync.Once
+		firstErr  error
+		companies = make([][]Company, len(seeds))
+	)
+	for i, seed := range seeds {
+		var seedParams SearchSimilarCompaniesParams
+		if params != nil {
+			seedParams = *params
+		}
+		seedParams.Domains = []string{seed.Domain}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			response, err := c.SearchSimilarCompanies(ctx, &seedParams)
+			if err == nil && response.StatusCode() != http.StatusOK {
+				err = c.baseClient.responseError(response.HTTPResponse, response.Body)
+			}
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			if response.JSON200 != nil {
+				companies[i] = response.JSON200.Companies
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return mergeSimilarCompanies(seeds, companies), nil
+}
+
+// mergeSimilarCompanies merges the results of the per-seed searches of
+// SearchSimilarCompaniesWeighted, results[i] being the companies similar to seeds[i]
+func mergeSimilarCompanies(seeds []SimilaritySeed, results [][]Company) []WeightedSimilarCompany {
+	excluded := make(map[string]bool, len(seeds))
+	totalWeight := 0.0
+	for _, seed := range seeds {
+		excluded[strings.ToLower(seed.Domain)] = true
+		totalWeight += seedWeight(seed)
+	}
+
+	index := make(map[string]int)
+	var merged []WeightedSimilarCompany
+	for i, companies := range results {
+		weight := seedWeight(seeds[i])
+		for _, company := range companies {
+			domain := strings.ToLower(company.DomainName())
+			if domain == "" || excluded[domain] {
+				continue
+			}
+			position, ok := index[domain]
+			if !ok {
+				position = len(merged)
+				index[domain] = position
+				merged = append(merged, WeightedSimilarCompany{Company: company})
+			}
+			merged[position].Score += weight * companySimilarity(company) / totalWeight
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Score > merged[j].Score
+	})
+	return merged
+}
+
+// validateSimilaritySeeds checks that the weights of the seeds have a positive sum of
+// finite, non-negative weights
+func validateSimilaritySeeds(seeds []SimilaritySeed) error {
+	totalWeight := 0.0
+	for _, seed := range seeds {
+		weight := seedWeight(seed)
+		if weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return fmt.Errorf("%w: %v for %s", ErrInvalidSimilarityWeight, seed.Weight, seed.Domain)
+		}
+		totalWeight += weight
+	}
+	if totalWeight <= 0 || math.IsInf(totalWeight, 0) {
+		return fmt.Errorf("%w: the total weight of the seeds is %v", ErrInvalidSimilarityWeight, totalWeight)
+	}
+	return nil
+}
+
+// seedWeight returns the weight of a seed, defaulting to 1
+func seedWeight(seed SimilaritySeed) float64 {
+	if seed.Weight == 0 {
+		return 1
+	}
+	return seed.Weight
+}
+
+// companySimilarity returns the similarity score of a company found by a similar search.
+// Results missing a score are considered fully similar.
+func companySimilarity(company Company) float64 {
+	if company.Meta == nil || company.Meta.Similarity == nil {
+		return 1
+	}
+	return float64(*company.Meta.Similarity)
+}
@@ -0,0 +1,108 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMergeSimilarCompanies(t *testing.T) {
+	company := func(domain string, similarity float32) Company {
+		return testCompany(t, fmt.Sprintf(`{"domain":{"domain":%q},"meta":{"similarity":%v}}`, domain, similarity))
+	}
+	seeds := []SimilaritySeed{{Domain: "stripe.com", Weight: 3}, {Domain: "adyen.com"}}
+	results := [][]Company{
+		{company("paypal.com", 0.9), company("square.com", 0.5), company("adyen.com", 0.8)},
+		{company("square.com", 1), company("Worldline.com", 0.6)},
+	}
+
+	merged := mergeSimilarCompanies(seeds, results)
+	expected := []struct {
+		domain string
+		score  float64
+	}{
+		{"paypal.com", 3 * 0.9 / 4},
+		{"square.com", (3*0.5 + 1) / 4},
+		{"Worldline.com", 0.6 / 4},
+	}
+	if len(merged) != len(expected) {
+		t.Fatalf("Expected %d companies, got %d", len(expected), len(merged))
+	}
+	for i, e := range expected {
+		if merged[i].Company.DomainName() != e.domain || math.Abs(merged[i].Score-e.score) > 1e-6 {
+			t.Errorf("Expected %s with score %v at %d, got %s with %v", e.domain, e.score, i, merged[i].Company.DomainName(), merged[i].Score)
+		}
+	}
+}
+
+func TestSearchSimilarCompaniesWeighted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("domains") {
+		case "stripe.com":
+			w.Write([]byte(`{"companies":[{"domain":{"domain":"paypal.com"},"meta":{"similarity":0.4}}],"meta":{}}`))
+		default:
+			w.Write([]byte(`{"companies":[{"domain":{"domain":"square.com"},"meta":{"similarity":0.9}}],"meta":{}}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	seeds := []SimilaritySeed{{Domain: "stripe.com", Weight: 4}, {Domain: "adyen.com", Weight: 1}}
+	companies, err := client.SearchSimilarCompaniesWeighted(context.Background(), seeds, nil)
+	if err != nil {
+		t.Fatalf("SearchSimilarCompaniesWeighted returned error: %v", err)
+	}
+	// The heavier seed outweighs the higher similarity: 4*0.4/5 > 0.9/5
+	if len(companies) != 2 || companies[0].Company.DomainName() != "paypal.com" {
+		t.Errorf("Expected paypal.com first, got %+v", companies)
+	}
+}
+
+func TestSearchSimilarCompaniesWeightedValidation(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"companies":[{"domain":{"domain":"square.com"},"meta":{"similarity":0.9}}],"meta":{}}`))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+	ctx := context.Background()
+
+	for _, seeds := range [][]SimilaritySeed{
+		nil,
+		{{Domain: "stripe.com", Weight: -1}, {Domain: "adyen.com", Weight: 1}},
+		{{Domain: "stripe.com", Weight: math.NaN()}},
+		{{Domain: "stripe.com", Weight: math.Inf(1)}},
+	} {
+		if _, err := client.SearchSimilarCompaniesWeighted(ctx, seeds, nil); !errors.Is(err, ErrInvalidSimilarityWeight) {
+			t.Errorf("Expected ErrInvalidSimilarityWeight for %v, got %v", seeds, err)
+		}
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("Expected no search for invalid weights, got %d", n)
+	}
+
+	// Zero weights count as 1
+	companies, err := client.SearchSimilarCompaniesWeighted(ctx, []SimilaritySeed{{Domain: "stripe.com"}, {Domain: "adyen.com"}}, nil)
+	if err != nil {
+		t.Fatalf("SearchSimilarCompaniesWeighted returned error: %v", err)
+	}
+	if len(companies) != 1 || math.Abs(companies[0].Score-0.9) > 1e-6 {
+		t.Errorf("Expected square.com with a score of 0.9, got %+v", companies)
+	}
+}
@@ -0,0 +1,49 @@
+package thecompaniesapi
+
+import (
+	"net/http"
+	"time"
+)
+
+// SlowRequestEvent describes a request attempt slower than the threshold set by
+// WithSlowRequestThreshold
+type SlowRequestEvent struct {
+	// Operation is the API operation of the request, e.g. "FetchCompany"
+	Operation string
+	// Method and Path are the method and URL path of the request
+	Method string
+	Path   string
+	// Duration is how long the attempt took, until its response headers or error
+	Duration time.Duration
+	// Threshold is the threshold that was exceeded
+	Threshold time.Duration
+}
+
+// WithSlowRequestThreshold flags the request attempts lasting longer than threshold,
+// to catch performance regressions: they are logged at the Warn level by WithLogger
+// and reported to the SlowRequest hook of WithObserver. Each retry attempt is timed
+// separately. A threshold of 0 disables the detection.
+func WithSlowRequestThreshold(threshold time.Duration) BaseClientOption {
+	return func(c *BaseClient) {
+		c.slowThreshold = threshold
+	}
+}
+
+// isSlowRequest reports whether a request attempt exceeded the slow request threshold
+func (c *BaseClient) isSlowRequest(duration time.Duration) bool {
+	return c.slowThreshold > 0 && duration > c.slowThreshold
+}
+
+// observeDuration reports a slow request attempt to the observer
+func (c *BaseClient) observeDuration(req *http.Request, duration time.Duration) {
+	if !c.isSlowRequest(duration) || c.observer == nil || c.observer.SlowRequest == nil {
+		return
+	}
+	c.observer.SlowRequest(SlowRequestEvent{
+		Operation: requestOperationName(req),
+		Method:    req.Method,
+		Path:      req.URL.Path,
+		Duration:  duration,
+		Threshold: c.slowThreshold,
+	})
+}
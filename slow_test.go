@@ -0,0 +1,55 @@
+package thecompaniesapi
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithSlowRequestThreshold(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/companies/slow.com" {
+			time.Sleep(50 * time.Millisecond)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	var events []SlowRequestEvent
+	client := NewBaseClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+		WithObserver(Observer{SlowRequest: func(event SlowRequestEvent) {
+			events = append(events, event)
+		}}),
+		WithSlowRequestThreshold(20*time.Millisecond),
+	)
+	ctx := context.Background()
+
+	client.MakeRequest(ctx, "GET", "/v2/companies/fast.com", nil)
+	if strings.Contains(logs.String(), "slow request") || len(events) != 0 {
+		t.Fatalf("Expected the fast request not to be flagged, got %s and %v", logs.String(), events)
+	}
+
+	logs.Reset()
+	client.MakeRequest(ctx, "GET", "/v2/companies/slow.com", nil)
+	line := logs.String()
+	if !strings.Contains(line, "level=WARN") || !strings.Contains(line, "slow request") ||
+		!strings.Contains(line, "operation=FetchCompany") || !strings.Contains(line, "threshold=20ms") {
+		t.Errorf("Expected a slow request warning, got %s", line)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 slow request event, got %v", events)
+	}
+	if event := events[0]; event.Operation != "FetchCompany" || event.Path != "/v2/companies/slow.com" ||
+		event.Duration < 50*time.Millisecond || event.Threshold != 20*time.Millisecond {
+		t.Errorf("Unexpected event: %+v", event)
+	}
+}
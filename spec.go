@@ -0,0 +1,44 @@
+package thecompaniesapi
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+var (
+	embeddedSpecOnce sync.Once
+	embeddedSpecDoc  *openapi3.T
+	embeddedSpecErr  error
+)
+
+// embeddedSpec decodes the OpenAPI specification embedded in generated.go once
+func embeddedSpec() (*openapi3.T, error) {
+	embeddedSpecOnce.Do(func() {
+		embeddedSpecDoc, embeddedSpecErr = GetSwagger()
+	})
+	return embeddedSpecDoc, embeddedSpecErr
+}
+
+// schemaPropertyEnum returns the allowed values of a property of a component schema
+func schemaPropertyEnum(schema, property string) ([]string, error) {
+	spec, err := embeddedSpec()
+	if err != nil {
+		return nil, err
+	}
+	ref, ok := spec.Components.Schemas[schema]
+	if !ok || ref.Value == nil {
+		return nil, fmt.Errorf("schema %s not found in the OpenAPI specification", schema)
+	}
+	propertyRef, ok := ref.Value.Properties[property]
+	if !ok || propertyRef.Value == nil {
+		return nil, fmt.Errorf("property %s not found in schema %s", property, schema)
+	}
+
+	values := make([]string, 0, len(propertyRef.Value.Enum))
+	for _, value := range propertyRef.Value.Enum {
+		values = append(values, fmt.Sprint(value))
+	}
+	return values, nil
+}
@@ -0,0 +1,105 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// StreamSearchCompaniesPost runs a search like SearchCompaniesPost but decodes the
+// companies of the response incrementally, calling fn for every company as soon as it
+// is read instead of buffering the whole page. Memory stays flat for large sizes since
+// a single company is held at a time. The pagination metadata of the page is returned
+// once the response is fully read. Decoding stops at the first error returned by fn,
+// which is then returned; unsuccessful responses are returned as errors.
+func (c *CompaniesAPIClient) StreamSearchCompaniesPost(ctx context.Context, body SearchCompaniesPostJSONRequestBody, fn func(company Company) error, reqEditors ...RequestEditorFn) (PaginationMeta, error) {
+	resp, err := c.ClientWithResponses.ClientInterface.SearchCompaniesPost(withStreamedResponse(c.callContext(ctx)), body, reqEditors...)
+	if err != nil {
+		return PaginationMeta{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		responseBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return PaginationMeta{}, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return PaginationMeta{}, c.baseClient.responseError(resp, responseBody)
+	}
+	meta, rawMeta, err := decodeCompaniesStream(json.NewDecoder(resp.Body), fn)
+	if err == nil && c.baseClient.tracksCredits() {
+		// The response is not buffered by the client: its credits are reported once decoded
+		credits, remaining, ok := parseMetaCredits(rawMeta)
+		c.baseClient.reportCredits(resp.Request, credits, remaining, ok)
+	}
+	return meta, err
+}
+
+// decodeCompaniesStream decodes a {"companies": [...], "meta": {...}} object, calling fn
+// for every company of the array as it is decoded. The meta object is also returned
+// undecoded.
+func decodeCompaniesStream(decoder *json.Decoder, fn func(company Company) error) (PaginationMeta, json.RawMessage, error) {
+	var (
+		meta    PaginationMeta
+		rawMeta json.RawMessage
+	)
+	if err := expectDelim(decoder, '{'); err != nil {
+		return meta, nil, err
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return meta, nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+		switch token {
+		case "companies":
+			if err := decodeCompaniesArray(decoder, fn); err != nil {
+				return meta, nil, err
+			}
+		case "meta":
+			if err := decoder.Decode(&rawMeta); err != nil {
+				return meta, nil, fmt.Errorf("failed to decode response meta: %w", err)
+			}
+			if err := json.Unmarshal(rawMeta, &meta); err != nil {
+				return meta, nil, fmt.Errorf("failed to decode response meta: %w", err)
+			}
+		default:
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				return meta, nil, fmt.Errorf("failed to decode response: %w", err)
+			}
+		}
+	}
+	return meta, rawMeta, expectDelim(decoder, '}')
+}
+
+// decodeCompaniesArray decodes the companies array one company at a time
+func decodeCompaniesArray(decoder *json.Decoder, fn func(company Company) error) error {
+	if err := expectDelim(decoder, '['); err != nil {
+		return err
+	}
+	for decoder.More() {
+		var company Company
+		if err := decoder.Decode(&company); err != nil {
+			return fmt.Errorf("failed to decode company: %w", err)
+		}
+		if err := fn(company); err != nil {
+			return err
+		}
+	}
+	return expectDelim(decoder, ']')
+}
+
+// expectDelim reads the next token, failing when it is not the delimiter
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if token != delim {
+		return fmt.Errorf("failed to decode response: expected %v, got %v", delim, token)
+	}
+	return nil
+}
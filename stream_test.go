@@ -0,0 +1,100 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestStreamSearchCompaniesPost(t *testing.T) {
+	const total = 5000
+	firstDecoded := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"companies":[`)
+		for i := 0; i < total; i++ {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"domain":{"domain":"company-%d.com"},"about":{"name":"Company %d"}}`, i, i)
+			if i == total/2 {
+				// Companies are decoded while the response is still being written
+				w.(http.Flusher).Flush()
+				select {
+				case <-firstDecoded:
+				case <-time.After(5 * time.Second):
+					t.Error("Expected the first company before the end of the response")
+				}
+			}
+		}
+		fmt.Fprintf(w, `],"meta":{"total":%d,"currentPage":1,"lastPage":1},"query":[]}`, total)
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	decoded := 0
+	meta, err := client.StreamSearchCompaniesPost(context.Background(), SearchCompaniesPostJSONRequestBody{}, func(company Company) error {
+		if expected := fmt.Sprintf("company-%d.com", decoded); company.DomainName() != expected {
+			t.Fatalf("Expected %s, got %s", expected, company.DomainName())
+		}
+		if decoded == 0 {
+			close(firstDecoded)
+		}
+		decoded++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamSearchCompaniesPost returned error: %v", err)
+	}
+	if decoded != total || meta.Total != total {
+		t.Errorf("Expected %d companies, got %d callbacks and a total of %v", total, decoded, meta.Total)
+	}
+}
+
+func TestStreamSearchCompaniesPostErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("fail") != "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"code":"unauthorized","message":"Invalid API token"}`))
+			return
+		}
+		w.Write([]byte(`{"companies":[{"domain":{"domain":"apple.com"}},{"domain":{"domain":"stripe.com"}}],"meta":{}}`))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+	ctx := context.Background()
+
+	// The callback error stops the decoding
+	stop := errors.New("stop")
+	calls := 0
+	_, err = client.StreamSearchCompaniesPost(ctx, SearchCompaniesPostJSONRequestBody{}, func(company Company) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("Expected the callback error after 1 call, got %v after %d", err, calls)
+	}
+
+	fail := func(ctx context.Context, req *http.Request) error {
+		req.URL.RawQuery = "fail=1"
+		return nil
+	}
+	_, err = client.StreamSearchCompaniesPost(ctx, SearchCompaniesPostJSONRequestBody{}, func(Company) error { return nil }, fail)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Code != "unauthorized" {
+		t.Errorf("Expected the API error, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"net/http"
+)
+
+// TeamSettings are the settings of a team that can be changed with UpdateTeam
+type TeamSettings = UpdateTeamJSONRequestBody
+
+// UpdateTeamSettings changes the settings of a team with a read-modify-write: it
+// fetches the team, lets mutate change its current settings and submits the
+// settings changed by mutate with UpdateTeam, leaving the other ones untouched.
+// Settings set to nil are omitted from the update and therefore keep their value.
+// The API has no conditional update, so a concurrent change of the same setting
+// between the fetch and the update is overwritten. When mutate changes nothing no
+// update is sent and the fetched team is returned. As with DeleteListByName, the
+// fetch may use at most half of the time left before the deadline of ctx.
+func (c *CompaniesAPIClient) UpdateTeamSettings(ctx context.Context, teamId float32, mutate func(settings *TeamSettings)) (*Team, error) {
+	budget := c.newCallBudget(ctx, 2)
+	fetchCtx, cancel := budget.next(ctx)
+	fetched, err := c.FetchTeam(fetchCtx, teamId)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	if fetched.StatusCode() != http.StatusOK || fetched.JSON200 == nil {
+		return nil, c.baseClient.responseError(fetched.HTTPResponse, fetched.Body)
+	}
+
+	team := fetched.JSON200
+	current := TeamSettings{Country: team.Country, Name: team.Name, WebsiteUrl: team.WebsiteUrl}
+	settings := TeamSettings{
+		Country:    copyString(current.Country),
+		Name:       copyString(current.Name),
+		WebsiteUrl: copyString(current.WebsiteUrl),
+	}
+	mutate(&settings)
+
+	changes := TeamSettings{
+		Country:    changedString(current.Country, settings.Country),
+		Name:       changedString(current.Name, settings.Name),
+		WebsiteUrl: changedString(current.WebsiteUrl, settings.WebsiteUrl),
+	}
+	if changes == (TeamSettings{}) {
+		return team, nil
+	}
+
+	updateCtx, cancel := budget.next(ctx)
+	defer cancel()
+	updated, err := c.UpdateTeam(updateCtx, teamId, changes)
+	if err != nil {
+		return nil, err
+	}
+	if updated.StatusCode() != http.StatusOK || updated.JSON200 == nil {
+		return nil, c.baseClient.responseError(updated.HTTPResponse, updated.Body)
+	}
+	return updated.JSON200, nil
+}
+
+// copyString returns a pointer to a copy of *s, nil when s is nil
+func copyString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	value := *s
+	return &value
+}
+
+// changedString returns next when it sets a value different from previous, nil otherwise
+func changedString(previous, next *string) *string {
+	if next == nil || (previous != nil && *previous == *next) {
+		return nil
+	}
+	return next
+}
@@ -0,0 +1,62 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUpdateTeamSettings(t *testing.T) {
+	var updates []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/teams/7" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"id":7,"name":"Acme","country":"FR","websiteUrl":"https://acme.com","credits":10,"creditsPack":0,"stripeSubscribed":false}`))
+			return
+		}
+		var update map[string]any
+		json.NewDecoder(r.Body).Decode(&update)
+		updates = append(updates, update)
+		w.Write([]byte(`{"id":7,"name":"Acme Inc","country":"FR","websiteUrl":"https://acme.com","credits":10,"creditsPack":0,"stripeSubscribed":false}`))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	team, err := client.UpdateTeamSettings(context.Background(), 7, func(settings *TeamSettings) {
+		if settings.Name == nil || *settings.Name != "Acme" {
+			t.Errorf("Expected the current settings, got %+v", settings)
+		}
+		name := "Acme Inc"
+		settings.Name = &name
+	})
+	if err != nil {
+		t.Fatalf("UpdateTeamSettings returned error: %v", err)
+	}
+	if team.Name == nil || *team.Name != "Acme Inc" {
+		t.Errorf("Expected the updated team, got %+v", team)
+	}
+	// Only the mutated setting is sent
+	if len(updates) != 1 || len(updates[0]) != 1 || updates[0]["name"] != "Acme Inc" {
+		t.Errorf("Unexpected updates: %v", updates)
+	}
+
+	// Nothing is sent when the callback changes nothing
+	country := "FR"
+	if _, err := client.UpdateTeamSettings(context.Background(), 7, func(settings *TeamSettings) {
+		settings.Country = &country
+	}); err != nil {
+		t.Fatalf("UpdateTeamSettings returned error: %v", err)
+	}
+	if len(updates) != 1 {
+		t.Errorf("Expected no update to be sent, got %v", updates[1:])
+	}
+}
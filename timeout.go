@@ -0,0 +1,105 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// ErrReadTimeout is returned when no response bytes were received within the read
+// timeout configured with WithReadTimeout
+var ErrReadTimeout = errors.New("response body read timed out")
+
+// WithReadTimeout aborts requests whose response body stalls: the timeout is reset
+// every time bytes are received, so long downloads keep going as long as data flows.
+// It complements WithTimeout, which bounds the whole request duration.
+func WithReadTimeout(timeout time.Duration) BaseClientOption {
+	return func(c *BaseClient) {
+		c.middlewares = append(c.middlewares, func(next roundTripFunc) roundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				ctx, cancel := context.WithCancel(req.Context())
+				resp, err := next(req.WithContext(ctx))
+				if err != nil {
+					cancel()
+					return resp, err
+				}
+				resp.Body = newIdleTimeoutBody(resp.Body, timeout, cancel)
+				return resp, nil
+			}
+		})
+	}
+}
+
+// idleTimeoutBody cancels the request when no bytes are read from the body within timeout
+type idleTimeoutBody struct {
+	body     io.ReadCloser
+	timeout  time.Duration
+	timer    *time.Timer
+	cancel   context.CancelFunc
+	timedOut atomic.Bool
+}
+
+func newIdleTimeoutBody(body io.ReadCloser, timeout time.Duration, cancel context.CancelFunc) *idleTimeoutBody {
+	b := &idleTimeoutBody{body: body, timeout: timeout, cancel: cancel}
+	b.timer = time.AfterFunc(timeout, func() {
+		b.timedOut.Store(true)
+		cancel()
+	})
+	return b
+}
+
+func (b *idleTimeoutBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	if b.timedOut.Load() {
+		return n, ErrReadTimeout
+	}
+	if n > 0 {
+		b.timer.Reset(b.timeout)
+	}
+	return n, err
+}
+
+func (b *idleTimeoutBody) Close() error {
+	b.timer.Stop()
+	b.cancel()
+	return b.body.Close()
+}
+
+// WithDefaultRequestTimeout gives every request whose context has no deadline a
+// deadline of timeout, spanning all its retries and the read of the response body.
+// Contexts carrying their own deadline are left untouched, even a later one. Unlike
+// WithTimeout, which configures the underlying http.Client, the deadline is set on the
+// request context so that middlewares, token providers and transports observe it.
+func WithDefaultRequestTimeout(timeout time.Duration) BaseClientOption {
+	return func(c *BaseClient) {
+		c.defaultRequestTimeout = timeout
+	}
+}
+
+// withDefaultDeadline applies the default request timeout to a request without deadline.
+// The returned function releases the deadline, it is nil when none was set.
+func (c *BaseClient) withDefaultDeadline(req *http.Request) (*http.Request, context.CancelFunc) {
+	if c.defaultRequestTimeout <= 0 {
+		return req, nil
+	}
+	if _, ok := req.Context().Deadline(); ok {
+		return req, nil
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), c.defaultRequestTimeout)
+	return req.WithContext(ctx), cancel
+}
+
+// cancelOnCloseBody releases the context of a request once its response body is closed
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
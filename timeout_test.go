@@ -0,0 +1,98 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithReadTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"companies":[`))
+		w.(http.Flusher).Flush()
+		if r.URL.Path == "/stalled" {
+			<-release
+			return
+		}
+		for i := 0; i < 3; i++ {
+			time.Sleep(20 * time.Millisecond)
+			w.Write([]byte(`{},`))
+			w.(http.Flusher).Flush()
+		}
+		w.Write([]byte(`{}]}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewBaseClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithReadTimeout(100*time.Millisecond),
+	)
+
+	// A slow but steady body keeps resetting the timeout
+	if _, err := client.MakeRequest(context.Background(), "GET", "/streaming", nil); err != nil {
+		t.Fatalf("Expected a steady stream to succeed, got %v", err)
+	}
+
+	start := time.Now()
+	_, err := client.MakeRequest(context.Background(), "GET", "/stalled", nil)
+	if !errors.Is(err, ErrReadTimeout) {
+		t.Fatalf("Expected ErrReadTimeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the stalled request to abort quickly, took %v", elapsed)
+	}
+}
+
+// deadlineRecorder records the deadline of the requests it forwards
+type deadlineRecorder struct {
+	deadline time.Time
+	ok       bool
+}
+
+func (r *deadlineRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.deadline, r.ok = req.Context().Deadline()
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestWithDefaultRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	recorder := &deadlineRecorder{}
+	client, err := ApiClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithCustomHTTPClient(&http.Client{Transport: recorder}),
+		WithDefaultRequestTimeout(time.Minute),
+	)
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	// A context without deadline gets the default one
+	start := time.Now()
+	if _, err := client.FetchCompany(context.Background(), "apple.com", nil); err != nil {
+		t.Fatalf("FetchCompany returned error: %v", err)
+	}
+	if !recorder.ok || recorder.deadline.Before(start.Add(time.Minute)) || recorder.deadline.After(time.Now().Add(time.Minute)) {
+		t.Errorf("Expected a default deadline in a minute, got %v (%v)", recorder.deadline, recorder.ok)
+	}
+
+	// An explicit deadline is preserved, even a later one
+	deadline := time.Now().Add(time.Hour)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	if _, err := client.FetchCompany(ctx, "apple.com", nil); err != nil {
+		t.Fatalf("FetchCompany returned error: %v", err)
+	}
+	if !recorder.deadline.Equal(deadline) {
+		t.Errorf("Expected the explicit deadline %v, got %v", deadline, recorder.deadline)
+	}
+}
@@ -0,0 +1,94 @@
+package thecompaniesapi
+
+import (
+	"crypto/tls"
+	"net/http"
+	"os"
+)
+
+// WithMinTLSVersion refuses TLS versions older than version (e.g. tls.VersionTLS12).
+//
+// The option composes with the other options: it applies to a clone of the transport
+// of the HTTP client, http.DefaultTransport by default or the *http.Transport of a
+// client set with WithCustomHTTPClient, which is left untouched. Custom transports that
+// are not an *http.Transport must enforce the version themselves.
+func WithMinTLSVersion(version uint16) BaseClientOption {
+	return func(c *BaseClient) {
+		c.minTLSVersion = version
+	}
+}
+
+// WithInsecureSkipVerify disables the verification of the server certificate, to call
+// local or staging servers using self-signed certificates.
+//
+// WARNING: this exposes requests, API token included, to man-in-the-middle attacks. It
+// must never be used in production. As a safeguard the option is ignored unless the
+// TCA_INSECURE_SKIP_VERIFY environment variable is set to "true", so that a build
+// enabling it by mistake keeps verifying certificates where the variable is not set.
+//
+// Like WithMinTLSVersion, it applies to a clone of the transport of the HTTP client.
+func WithInsecureSkipVerify() BaseClientOption {
+	return func(c *BaseClient) {
+		c.insecureSkipVerify = os.Getenv(EnvInsecureSkipVerify) == "true"
+	}
+}
+
+// WithForceHTTP1 restricts the client to HTTP/1.1, for environments where HTTP/2 to
+// the API fails, such as behind some proxies. HTTP/2 is neither attempted nor
+// negotiated over TLS.
+//
+// Like WithMinTLSVersion, it applies to a clone of the transport of the HTTP client.
+func WithForceHTTP1() BaseClientOption {
+	return func(c *BaseClient) {
+		c.forceHTTP1 = true
+	}
+}
+
+// applyTransportConfig installs a transport enforcing the TLS and protocol options,
+// once all options are applied so that it does not depend on their order
+func (c *BaseClient) applyTransportConfig() {
+	if c.minTLSVersion == 0 && !c.insecureSkipVerify && !c.forceHTTP1 {
+		return
+	}
+
+	base := c.httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	transport, ok := base.(*http.Transport)
+	if !ok {
+		return
+	}
+
+	transport = transport.Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	if transport.TLSClientConfig.MinVersion < c.minTLSVersion {
+		transport.TLSClientConfig.MinVersion = c.minTLSVersion
+	}
+	if c.insecureSkipVerify {
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	}
+	if c.forceHTTP1 {
+		// A non-nil empty map disables the HTTP/2 upgrade of TLS connections
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+		transport.TLSClientConfig.NextProtos = withoutProtocol(transport.TLSClientConfig.NextProtos, "h2")
+	}
+
+	httpClient := *c.httpClient
+	httpClient.Transport = transport
+	c.httpClient = &httpClient
+}
+
+// withoutProtocol returns a copy of the ALPN protocols without protocol
+func withoutProtocol(protocols []string, protocol string) []string {
+	var kept []string
+	for _, p := range protocols {
+		if p != protocol {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
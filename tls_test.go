@@ -0,0 +1,126 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithMinTLSVersion(t *testing.T) {
+	client := NewBaseClient("test-api-key", WithMinTLSVersion(tls.VersionTLS12), WithTimeout(5*time.Second))
+
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Expected *http.Transport, got %T", client.httpClient.Transport)
+	}
+	if transport.TLSClientConfig == nil || transport.TLSClientConfig.MinVersion != tls.VersionTLS12 {
+		t.Errorf("Expected MinVersion TLS 1.2, got %+v", transport.TLSClientConfig)
+	}
+	if client.httpClient.Timeout != 5*time.Second {
+		t.Errorf("Expected timeout to be preserved, got %v", client.httpClient.Timeout)
+	}
+	if config := http.DefaultTransport.(*http.Transport).TLSClientConfig; config != nil && config.MinVersion != 0 {
+		t.Error("Expected http.DefaultTransport to be left untouched")
+	}
+
+	// A custom client is configured regardless of the option order, without being mutated
+	custom := &http.Client{Transport: &http.Transport{}}
+	client = NewBaseClient("test-api-key", WithMinTLSVersion(tls.VersionTLS13), WithCustomHTTPClient(custom))
+	if got := client.httpClient.Transport.(*http.Transport).TLSClientConfig.MinVersion; got != tls.VersionTLS13 {
+		t.Errorf("Expected MinVersion TLS 1.3, got %x", got)
+	}
+	if config := custom.Transport.(*http.Transport).TLSClientConfig; config != nil && config.MinVersion != 0 {
+		t.Error("Expected the custom transport to be left untouched")
+	}
+}
+
+func TestWithMinTLSVersionHandshake(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+
+	serverTransport := server.Client().Transport.(*http.Transport)
+	client := NewBaseClient("test-api-key",
+		WithCustomBaseURL(server.URL),
+		WithCustomHTTPClient(&http.Client{Transport: serverTransport}),
+		WithMinTLSVersion(tls.VersionTLS13),
+	)
+	if _, err := client.MakeRequest(context.Background(), "GET", "/v2/health", nil); err == nil {
+		t.Error("Expected the TLS 1.2 server to be refused")
+	}
+}
+
+func TestWithInsecureSkipVerify(t *testing.T) {
+	// Without the environment gate the option is ignored
+	t.Setenv(EnvInsecureSkipVerify, "")
+	client := NewBaseClient("test-api-key", WithInsecureSkipVerify())
+	if transport, ok := client.httpClient.Transport.(*http.Transport); ok && transport.TLSClientConfig != nil && transport.TLSClientConfig.InsecureSkipVerify {
+		t.Error("Expected certificates to be verified without the environment gate")
+	}
+
+	t.Setenv(EnvInsecureSkipVerify, "true")
+	client = NewBaseClient("test-api-key", WithInsecureSkipVerify(), WithMinTLSVersion(tls.VersionTLS12))
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	if !ok || transport.TLSClientConfig == nil || !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Fatalf("Expected InsecureSkipVerify to be set, got %+v", client.httpClient.Transport)
+	}
+	if transport.TLSClientConfig.MinVersion != tls.VersionTLS12 {
+		t.Errorf("Expected the options to compose, got MinVersion %x", transport.TLSClientConfig.MinVersion)
+	}
+	if config := http.DefaultTransport.(*http.Transport).TLSClientConfig; config != nil && config.InsecureSkipVerify {
+		t.Error("Expected http.DefaultTransport to be left untouched")
+	}
+
+	// Self-signed certificates are accepted
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client = NewBaseClient("test-api-key", WithCustomBaseURL(server.URL), WithInsecureSkipVerify())
+	if _, err := client.MakeRequest(context.Background(), "GET", "/v2/user", nil); err != nil {
+		t.Errorf("Expected the self-signed certificate to be accepted, got %v", err)
+	}
+}
+
+func TestWithForceHTTP1(t *testing.T) {
+	client := NewBaseClient("test-api-key", WithForceHTTP1())
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Expected *http.Transport, got %T", client.httpClient.Transport)
+	}
+	if transport.ForceAttemptHTTP2 || transport.TLSNextProto == nil || len(transport.TLSNextProto) != 0 {
+		t.Errorf("Expected the transport to be restricted to HTTP/1.1, got ForceAttemptHTTP2=%v TLSNextProto=%v", transport.ForceAttemptHTTP2, transport.TLSNextProto)
+	}
+	if !http.DefaultTransport.(*http.Transport).ForceAttemptHTTP2 {
+		t.Error("Expected http.DefaultTransport to be left untouched")
+	}
+
+	var protocols []string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		protocols = append(protocols, r.Proto)
+		w.Write([]byte(`{}`))
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	// The test server client negotiates HTTP/2 unless forced to HTTP/1.1
+	for _, options := range [][]BaseClientOption{nil, {WithForceHTTP1()}} {
+		options = append(options, WithCustomBaseURL(server.URL), WithCustomHTTPClient(server.Client()))
+		if _, err := NewBaseClient("test-api-key", options...).MakeRequest(context.Background(), "GET", "/v2/user", nil); err != nil {
+			t.Fatalf("MakeRequest failed: %v", err)
+		}
+	}
+	if len(protocols) != 2 || protocols[0] != "HTTP/2.0" || protocols[1] != "HTTP/1.1" {
+		t.Errorf("Expected HTTP/2.0 then HTTP/1.1, got %v", protocols)
+	}
+}
@@ -0,0 +1,43 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Requester is implemented by the clients of this package (BaseClient and
+// CompaniesAPIClient) and lets MakeRequestTyped reach endpoints that are not covered
+// by the generated operations yet.
+type Requester interface {
+	makeRequest(ctx context.Context, method, path string, body any) ([]byte, error)
+}
+
+func (c *BaseClient) makeRequest(ctx context.Context, method, path string, body any) ([]byte, error) {
+	return c.MakeRequest(ctx, method, path, body)
+}
+
+func (c *CompaniesAPIClient) makeRequest(ctx context.Context, method, path string, body any) ([]byte, error) {
+	return c.baseClient.makeRequestWithEditors(ctx, method, path, body, c.scope)
+}
+
+// MakeRequestTyped performs an authenticated request like MakeRequest and unmarshals the
+// JSON response into a value of type T. It is an escape hatch for endpoints released
+// before the generated types catch up:
+//
+//	type Preview struct {
+//		Domain string `json:"domain"`
+//	}
+//	preview, err := thecompaniesapi.MakeRequestTyped[Preview](ctx, client, "GET", "/v2/preview/apple.com", nil)
+func MakeRequestTyped[T any](ctx context.Context, client Requester, method, path string, body any) (*T, error) {
+	responseBody, err := client.makeRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(T)
+	if err := json.Unmarshal(responseBody, result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,46 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMakeRequestTyped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"domain":"` + body["domain"] + `","signals":[{"name":"hiring","score":0.8}]}`))
+	}))
+	defer server.Close()
+
+	type preview struct {
+		Domain  string `json:"domain"`
+		Signals []struct {
+			Name  string  `json:"name"`
+			Score float64 `json:"score"`
+		} `json:"signals"`
+	}
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	for _, requester := range []Requester{client, client.baseClient} {
+		result, err := MakeRequestTyped[preview](context.Background(), requester, "POST", "/v2/preview", map[string]string{"domain": "apple.com"})
+		if err != nil {
+			t.Fatalf("MakeRequestTyped failed: %v", err)
+		}
+		if result.Domain != "apple.com" || len(result.Signals) != 1 || result.Signals[0].Score != 0.8 {
+			t.Errorf("Unexpected typed result: %+v", result)
+		}
+	}
+
+	if _, err := MakeRequestTyped[[]string](context.Background(), client, "GET", "/v2/preview", nil); err == nil {
+		t.Error("Expected an error when the response does not match the type")
+	}
+}
@@ -0,0 +1,101 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// companyOperations are the operations about a single company, whose 404 responses
+// mean that the company is not found. FetchCompanyInList is left out since its 404
+// may mean that the list is not found.
+var companyOperations = map[string]bool{
+	"FetchCompany":              true,
+	"FetchCompanyByEmail":       true,
+	"FetchCompanyBySocial":      true,
+	"FetchCompanyContext":       true,
+	"FetchCompanyEmailPatterns": true,
+}
+
+// isCompanyNotFound reports whether a response is the 404 of an operation about a
+// single company
+func isCompanyNotFound(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusNotFound && resp.Request != nil &&
+		companyOperations[requestOperationName(resp.Request)]
+}
+
+// FetchCompanyResult fetches a company by domain like FetchCompany and unwraps the
+// response. Unsuccessful responses are returned as errors, a 404 matching
+// ErrCompanyNotFound.
+func (c *CompaniesAPIClient) FetchCompanyResult(ctx context.Context, domain string, params *FetchCompanyParams, reqEditors ...RequestEditorFn) (*Company, error) {
+	response, err := c.FetchCompany(ctx, domain, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() != http.StatusOK {
+		return nil, c.baseClient.responseError(response.HTTPResponse, response.Body)
+	}
+	if response.JSON200 == nil {
+		return nil, fmt.Errorf("%w for %s", ErrCompanyNotFound, domain)
+	}
+	return response.JSON200, nil
+}
+
+// FetchCompanyFull fetches the complete record of a company, explicitly requesting a
+// non-simplified response, for instance to fill the fields missing from a cached
+// simplified record with Company.Merge. Errors are those of FetchCompanyResult.
+func (c *CompaniesAPIClient) FetchCompanyFull(ctx context.Context, domain string, reqEditors ...RequestEditorFn) (*Company, error) {
+	simplified := false
+	return c.FetchCompanyResult(ctx, domain, &FetchCompanyParams{Simplified: &simplified}, reqEditors...)
+}
+
+// FetchCompanyByEmailResult fetches the company of an email address like
+// FetchCompanyByEmail and unwraps the response. Unsuccessful responses are returned as
+// errors, a 404 or a response without company matching ErrCompanyNotFound.
+func (c *CompaniesAPIClient) FetchCompanyByEmailResult(ctx context.Context, params *FetchCompanyByEmailParams, reqEditors ...RequestEditorFn) (*Company, error) {
+	response, err := c.FetchCompanyByEmail(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() != http.StatusOK {
+		return nil, c.baseClient.responseError(response.HTTPResponse, response.Body)
+	}
+	if response.JSON200 == nil || response.JSON200.Company == nil {
+		return nil, fmt.Errorf("%w for %s", ErrCompanyNotFound, params.Email)
+	}
+	return response.JSON200.Company, nil
+}
+
+// FetchCompanyBySocialResult fetches a company by social profile like
+// FetchCompanyBySocial and unwraps the response. Unsuccessful responses are returned as
+// errors, a 404 matching ErrCompanyNotFound.
+func (c *CompaniesAPIClient) FetchCompanyBySocialResult(ctx context.Context, params *FetchCompanyBySocialParams, reqEditors ...RequestEditorFn) (*Company, error) {
+	response, err := c.FetchCompanyBySocial(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() != http.StatusOK {
+		return nil, c.baseClient.responseError(response.HTTPResponse, response.Body)
+	}
+	if response.JSON200 == nil {
+		return nil, ErrCompanyNotFound
+	}
+	return response.JSON200, nil
+}
+
+// FetchCompanyInListResult fetches a company of a list like FetchCompanyInList and
+// unwraps the response. Unsuccessful responses are returned as errors; a 404 does not
+// match ErrCompanyNotFound since it may mean that the list is not found.
+func (c *CompaniesAPIClient) FetchCompanyInListResult(ctx context.Context, listId float32, domain string, reqEditors ...RequestEditorFn) (*Company, error) {
+	response, err := c.FetchCompanyInList(ctx, listId, domain, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() != http.StatusOK {
+		return nil, c.baseClient.responseError(response.HTTPResponse, response.Body)
+	}
+	if response.JSON200 == nil {
+		return nil, fmt.Errorf("%w for %s", ErrCompanyNotFound, domain)
+	}
+	return response.JSON200, nil
+}
@@ -0,0 +1,82 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCompanyNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":"not_found","message":"Not found"}`))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+	ctx := context.Background()
+	profile := "https://linkedin.com/company/unknown"
+
+	lookups := map[string]func() (*Company, error){
+		"FetchCompanyResult": func() (*Company, error) {
+			return client.FetchCompanyResult(ctx, "unknown.com", nil)
+		},
+		"FetchCompanyByEmailResult": func() (*Company, error) {
+			return client.FetchCompanyByEmailResult(ctx, &FetchCompanyByEmailParams{Email: "jane@unknown.com"})
+		},
+		"FetchCompanyBySocialResult": func() (*Company, error) {
+			return client.FetchCompanyBySocialResult(ctx, &FetchCompanyBySocialParams{Linkedin: &profile})
+		},
+	}
+	for name, lookup := range lookups {
+		company, err := lookup()
+		if company != nil || !errors.Is(err, ErrCompanyNotFound) {
+			t.Errorf("%s: expected ErrCompanyNotFound, got %v", name, err)
+		}
+		// The API error stays available
+		var apiErr *Error
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+			t.Errorf("%s: expected the API error to be wrapped, got %v", name, err)
+		}
+	}
+
+	// 404 responses of other operations are not about a company
+	_, err = client.FetchCompaniesInLists(ctx, []float32{1}, nil)
+	if err == nil || errors.Is(err, ErrCompanyNotFound) {
+		t.Errorf("Expected a plain not found error for a list, got %v", err)
+	}
+	// The 404 of a company in a list may be about the list
+	_, err = client.FetchCompanyInListResult(ctx, 1, "unknown.com")
+	if err == nil || errors.Is(err, ErrCompanyNotFound) {
+		t.Errorf("Expected a plain not found error for a company in a list, got %v", err)
+	}
+}
+
+func TestFetchCompanyFull(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("simplified") != "false" {
+			t.Errorf("Expected a non-simplified request, got %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"about":{"name":"Apple"},"codes":{"naics":["334220"]}}`))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+	company, err := client.FetchCompanyFull(context.Background(), "apple.com")
+	if err != nil {
+		t.Fatalf("FetchCompanyFull returned error: %v", err)
+	}
+	if company.Codes == nil {
+		t.Errorf("Expected the full company, got %+v", company)
+	}
+}
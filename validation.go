@@ -0,0 +1,85 @@
+package thecompaniesapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ErrInvalidRequestBody is matched by the errors of requests rejected by
+// WithRequestValidation
+var ErrInvalidRequestBody = errors.New("invalid request body")
+
+// WithRequestValidation validates the JSON body of outgoing requests against the
+// schema of their operation in the embedded OpenAPI specification before sending them,
+// e.g. the conditions of SearchCompaniesPost. Invalid requests fail with an error
+// matching ErrInvalidRequestBody that describes the mismatch, without reaching the API.
+// Validation decodes every body, so it is meant as a safety net during development.
+// Requests to unknown operations or without a JSON body are sent as is.
+func WithRequestValidation() BaseClientOption {
+	return func(c *BaseClient) {
+		c.middlewares = append(c.middlewares, func(next roundTripFunc) roundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				if err := validateRequestBody(req); err != nil {
+					closeRequestBody(req.Body)
+					return nil, err
+				}
+				return next(req)
+			}
+		})
+	}
+}
+
+// validateRequestBody validates the JSON body of a request against its operation schema
+func validateRequestBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody || !isJSONContentType(req.Header.Get("Content-Type")) {
+		return nil
+	}
+	route := matchOperationRoute(req.Method, req.URL.Path)
+	if route == nil || route.operation.RequestBody == nil || route.operation.RequestBody.Value == nil {
+		return nil
+	}
+	mediaType := route.operation.RequestBody.Value.Content.Get("application/json")
+	if mediaType == nil || mediaType.Schema == nil || mediaType.Schema.Value == nil {
+		return nil
+	}
+
+	body, err := readRequestBody(req)
+	if err != nil {
+		return err
+	}
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrInvalidRequestBody, route.name, err)
+	}
+	if err := mediaType.Schema.Value.VisitJSON(value, openapi3.MultiErrors()); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrInvalidRequestBody, route.name, err)
+	}
+	return nil
+}
+
+// readRequestBody returns the body of a request without consuming it, using GetBody
+// when the request can be replayed
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
@@ -0,0 +1,47 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithRequestValidation(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"companies":[],"meta":{}}`))
+	}))
+	defer server.Close()
+
+	client, err := ApiClient("test-api-key", WithCustomBaseURL(server.URL), WithRequestValidation())
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+	ctx := context.Background()
+
+	valid := []SegmentationCondition{testCondition(SegmentationConditionAttributeAboutIndustries, And)}
+	valid[0].Values = []SegmentationCondition_Values_Item{}
+	if _, err := client.SearchCompaniesPost(ctx, SearchCompaniesPostJSONRequestBody{Query: &valid}); err != nil {
+		t.Fatalf("Expected a valid body to be sent, got %v", err)
+	}
+
+	invalid := []SegmentationCondition{testCondition("about.revenue", And)}
+	invalid[0].Values = []SegmentationCondition_Values_Item{}
+	_, err = client.SearchCompaniesPost(ctx, SearchCompaniesPostJSONRequestBody{Query: &invalid})
+	if !errors.Is(err, ErrInvalidRequestBody) {
+		t.Fatalf("Expected ErrInvalidRequestBody, got %v", err)
+	}
+	for _, expected := range []string{"SearchCompaniesPost", "attribute", "about.revenue"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected the error to mention %q, got %v", expected, err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected the invalid request not to be sent, got %d requests", requests)
+	}
+}
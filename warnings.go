@@ -0,0 +1,122 @@
+package thecompaniesapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Warning is a deprecation or data quality notice sent by the API in the Warning
+// (RFC 7234) or Tca-Warning response headers
+type Warning struct {
+	// Code is the warn-code of a Warning header (e.g. 299), 0 for Tca-Warning
+	Code int
+	// Agent is the warn-agent of a Warning header, empty for Tca-Warning
+	Agent string
+	Text  string
+}
+
+// ParseWarnings extracts the warnings of a response, for instance from the
+// HTTPResponse.Header of a generated response:
+//
+//	for _, warning := range thecompaniesapi.ParseWarnings(response.HTTPResponse.Header) {
+//		log.Printf("API warning: %s", warning.Text)
+//	}
+func ParseWarnings(header http.Header) []Warning {
+	var warnings []Warning
+	for _, value := range header.Values("Warning") {
+		warnings = append(warnings, parseWarningHeader(value)...)
+	}
+	for _, value := range header.Values("Tca-Warning") {
+		if text := strings.TrimSpace(value); text != "" {
+			warnings = append(warnings, Warning{Text: text})
+		}
+	}
+	return warnings
+}
+
+// parseWarningHeader parses a Warning header value made of comma separated
+// `warn-code warn-agent "warn-text" ["warn-date"]` entries. Malformed entries are skipped.
+func parseWarningHeader(value string) []Warning {
+	var warnings []Warning
+	rest := value
+	for {
+		rest = strings.TrimLeft(rest, " \t,")
+		if rest == "" {
+			return warnings
+		}
+
+		codeText, afterCode, _ := strings.Cut(rest, " ")
+		agent, afterAgent, _ := strings.Cut(strings.TrimLeft(afterCode, " "), " ")
+		text, afterText, ok := cutQuoted(strings.TrimLeft(afterAgent, " "))
+		if !ok {
+			return warnings
+		}
+		// Skip the optional warn-date
+		rest = strings.TrimLeft(afterText, " ")
+		if _, afterDate, ok := cutQuoted(rest); ok {
+			rest = afterDate
+		}
+
+		code, err := strconv.Atoi(codeText)
+		if err != nil {
+			continue
+		}
+		warnings = append(warnings, Warning{Code: code, Agent: agent, Text: text})
+	}
+}
+
+// cutQuoted reads the quoted string at the beginning of s, unescaping backslashes
+func cutQuoted(s string) (text, rest string, ok bool) {
+	if !strings.HasPrefix(s, `"`) {
+		return "", s, false
+	}
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+			}
+		case '"':
+			return b.String(), s[i+1:], true
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", s, false
+}
+
+// warningRecorder keeps the warnings of the last response received by a client
+type warningRecorder struct {
+	mu       sync.Mutex
+	warnings []Warning
+}
+
+func (r *warningRecorder) record(resp *http.Response) {
+	warnings := ParseWarnings(resp.Header)
+	r.mu.Lock()
+	r.warnings = warnings
+	r.mu.Unlock()
+}
+
+func (r *warningRecorder) last() []Warning {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Warning(nil), r.warnings...)
+}
+
+// LastWarnings returns the warnings of the last response received by the client.
+// When the client is shared by several goroutines the last response may belong to
+// another goroutine: use ParseWarnings on the response headers to get the warnings of
+// a specific call.
+func (c *BaseClient) LastWarnings() []Warning {
+	return c.warnings.last()
+}
+
+// LastWarnings returns the warnings of the last response received by the client
+func (c *CompaniesAPIClient) LastWarnings() []Warning {
+	return c.baseClient.LastWarnings()
+}
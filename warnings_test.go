@@ -0,0 +1,57 @@
+package thecompaniesapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseWarnings(t *testing.T) {
+	header := http.Header{}
+	header.Add("Warning", `299 api.thecompaniesapi.com "The \"simplified\" parameter is deprecated, use fields" "Wed, 14 Oct 2026 09:00:00 GMT"`)
+	header.Add("Warning", `199 - "Stale data", 214 proxy "Transformed"`)
+	header.Add("Tca-Warning", "Employee counts for this company are estimated")
+
+	expected := []Warning{
+		{Code: 299, Agent: "api.thecompaniesapi.com", Text: `The "simplified" parameter is deprecated, use fields`},
+		{Code: 199, Agent: "-", Text: "Stale data"},
+		{Code: 214, Agent: "proxy", Text: "Transformed"},
+		{Text: "Employee counts for this company are estimated"},
+	}
+	if warnings := ParseWarnings(header); !reflect.DeepEqual(warnings, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, warnings)
+	}
+
+	if warnings := ParseWarnings(http.Header{"Warning": {"not a warning"}}); len(warnings) != 0 {
+		t.Errorf("Expected malformed warnings to be skipped, got %+v", warnings)
+	}
+}
+
+func TestLastWarnings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/deprecated" {
+			w.Header().Set("Warning", `299 - "This endpoint is deprecated"`)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewBaseClient("test-api-key", WithCustomBaseURL(server.URL))
+	ctx := context.Background()
+
+	if _, err := client.MakeRequest(ctx, "GET", "/v2/deprecated", nil); err != nil {
+		t.Fatalf("MakeRequest returned error: %v", err)
+	}
+	if warnings := client.LastWarnings(); len(warnings) != 1 || warnings[0].Text != "This endpoint is deprecated" {
+		t.Errorf("Expected deprecation warning, got %+v", warnings)
+	}
+
+	if _, err := client.MakeRequest(ctx, "GET", "/v2/health", nil); err != nil {
+		t.Fatalf("MakeRequest returned error: %v", err)
+	}
+	if warnings := client.LastWarnings(); len(warnings) != 0 {
+		t.Errorf("Expected no warnings, got %+v", warnings)
+	}
+}
@@ -3,29 +3,36 @@ package thecompaniesapi
 import (
 	"context"
 	"fmt"
-	"net/http"
+	"strings"
 )
 
 // CompaniesAPIClient is the main client for interacting with The Companies API
 // It provides access to all API operations with proper type safety and authentication
+//
+// A CompaniesAPIClient is safe for concurrent use by multiple goroutines and should be
+// created once and reused: its configuration is immutable after ApiClient returns and
+// the state shared across requests (cached tokens, connection pool) is synchronized.
 type CompaniesAPIClient struct {
 	*ClientWithResponses // Generated operations with proper types
-	baseClient *BaseClient // Internal HTTP client (not exposed to users)
+	baseClient *BaseClient        // Internal HTTP client (not exposed to users)
+	scope      []RequestEditorFn // Request-level overrides of a client returned by With
 }
 
 // New creates the main client for The Companies API
 // This is the primary entry point that users should use
 func ApiClient(apiKey string, options ...BaseClientOption) (*CompaniesAPIClient, error) {
 	baseClient := NewBaseClient(apiKey, options...)
-	
-	// Create the generated client using the same base URL and HTTP client with authentication
+	if baseClient.optionErr != nil {
+		return nil, baseClient.optionErr
+	}
+	if err := validateBaseURL(baseClient.BaseURL()); err != nil {
+		return nil, err
+	}
+
+	// Create the generated client using the same base URL, routing requests through the base client pipeline
 	generatedClient, err := NewClientWithResponses(
-		baseClient.BaseURL(),
-		WithHTTPClient(baseClient.HTTPClient()),
-		WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
-			req.Header.Set("Authorization", "Basic "+apiKey)
-			return nil
-		}),
+		strings.TrimRight(baseClient.BaseURL(), "/"),
+		WithHTTPClient(baseClient),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create generated client: %w", err)
@@ -45,204 +52,222 @@ func (c *CompaniesAPIClient) BaseURL() string {
 
 // === API Health ===
 
-func (c *CompaniesAPIClient) FetchApiHealth(ctx context.Context) (*FetchApiHealthResponse, error) {
-	return c.ClientWithResponses.FetchApiHealthWithResponse(ctx)
+func (c *CompaniesAPIClient) FetchApiHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*FetchApiHealthResponse, error) {
+	return c.ClientWithResponses.FetchApiHealthWithResponse(c.callContext(ctx), reqEditors...)
 }
 
 // === Actions ===
 
-func (c *CompaniesAPIClient) FetchActions(ctx context.Context, params *FetchActionsParams) (*FetchActionsResponse, error) {
-	return c.ClientWithResponses.FetchActionsWithResponse(ctx, params)
+func (c *CompaniesAPIClient) FetchActions(ctx context.Context, params *FetchActionsParams, reqEditors ...RequestEditorFn) (*FetchActionsResponse, error) {
+	return c.ClientWithResponses.FetchActionsWithResponse(c.callContext(ctx), params, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) RequestAction(ctx context.Context, body RequestActionJSONRequestBody) (*RequestActionResponse, error) {
-	return c.ClientWithResponses.RequestActionWithResponse(ctx, body)
+func (c *CompaniesAPIClient) RequestAction(ctx context.Context, body RequestActionJSONRequestBody, reqEditors ...RequestEditorFn) (*RequestActionResponse, error) {
+	return c.ClientWithResponses.RequestActionWithResponse(c.callContext(ctx), body, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) RetryAction(ctx context.Context, actionId float32, body RetryActionJSONRequestBody) (*RetryActionResponse, error) {
-	return c.ClientWithResponses.RetryActionWithResponse(ctx, actionId, body)
+func (c *CompaniesAPIClient) RetryAction(ctx context.Context, actionId float32, body RetryActionJSONRequestBody, reqEditors ...RequestEditorFn) (*RetryActionResponse, error) {
+	return c.ClientWithResponses.RetryActionWithResponse(c.callContext(ctx), actionId, body, reqEditors...)
 }
 
 // === Companies Search ===
 
-func (c *CompaniesAPIClient) SearchCompanies(ctx context.Context, params *SearchCompaniesParams) (*SearchCompaniesResponse, error) {
-	return c.ClientWithResponses.SearchCompaniesWithResponse(ctx, params)
+func (c *CompaniesAPIClient) SearchCompanies(ctx context.Context, params *SearchCompaniesParams, reqEditors ...RequestEditorFn) (*SearchCompaniesResponse, error) {
+	return c.ClientWithResponses.SearchCompaniesWithResponse(c.callContext(ctx), params, reqEditors...)
+}
+
+func (c *CompaniesAPIClient) SearchCompaniesPost(ctx context.Context, body SearchCompaniesPostJSONRequestBody, reqEditors ...RequestEditorFn) (*SearchCompaniesPostResponse, error) {
+	return c.ClientWithResponses.SearchCompaniesPostWithResponse(c.callContext(ctx), body, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) SearchCompaniesPost(ctx context.Context, body SearchCompaniesPostJSONRequestBody) (*SearchCompaniesPostResponse, error) {
-	return c.ClientWithResponses.SearchCompaniesPostWithResponse(ctx, body)
+// SearchCompaniesPostGroup searches companies like SearchCompaniesPost with the query
+// built from a condition group with ConditionGroup.Flatten, returning
+// ErrConditionsNotCombinable before any request when the group cannot be flattened
+func (c *CompaniesAPIClient) SearchCompaniesPostGroup(ctx context.Context, body SearchCompaniesPostJSONRequestBody, group ConditionGroup, reqEditors ...RequestEditorFn) (*SearchCompaniesPostResponse, error) {
+	query, err := group.Flatten()
+	if err != nil {
+		return nil, err
+	}
+	body.Query = &query
+	return c.SearchCompaniesPost(ctx, body, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) SearchCompaniesByName(ctx context.Context, params *SearchCompaniesByNameParams) (*SearchCompaniesByNameResponse, error) {
-	return c.ClientWithResponses.SearchCompaniesByNameWithResponse(ctx, params)
+func (c *CompaniesAPIClient) SearchCompaniesByName(ctx context.Context, params *SearchCompaniesByNameParams, reqEditors ...RequestEditorFn) (*SearchCompaniesByNameResponse, error) {
+	return c.ClientWithResponses.SearchCompaniesByNameWithResponse(c.callContext(ctx), params, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) SearchCompaniesByPrompt(ctx context.Context, params *SearchCompaniesByPromptParams) (*SearchCompaniesByPromptResponse, error) {
-	return c.ClientWithResponses.SearchCompaniesByPromptWithResponse(ctx, params)
+func (c *CompaniesAPIClient) SearchCompaniesByPrompt(ctx context.Context, params *SearchCompaniesByPromptParams, reqEditors ...RequestEditorFn) (*SearchCompaniesByPromptResponse, error) {
+	return c.ClientWithResponses.SearchCompaniesByPromptWithResponse(c.callContext(ctx), params, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) SearchSimilarCompanies(ctx context.Context, params *SearchSimilarCompaniesParams) (*SearchSimilarCompaniesResponse, error) {
-	return c.ClientWithResponses.SearchSimilarCompaniesWithResponse(ctx, params)
+func (c *CompaniesAPIClient) SearchSimilarCompanies(ctx context.Context, params *SearchSimilarCompaniesParams, reqEditors ...RequestEditorFn) (*SearchSimilarCompaniesResponse, error) {
+	return c.ClientWithResponses.SearchSimilarCompaniesWithResponse(c.callContext(ctx), params, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) CountCompanies(ctx context.Context, params *CountCompaniesParams) (*CountCompaniesResponse, error) {
-	return c.ClientWithResponses.CountCompaniesWithResponse(ctx, params)
+func (c *CompaniesAPIClient) CountCompanies(ctx context.Context, params *CountCompaniesParams, reqEditors ...RequestEditorFn) (*CountCompaniesResponse, error) {
+	return c.ClientWithResponses.CountCompaniesWithResponse(c.callContext(ctx), params, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) CountCompaniesPost(ctx context.Context, body CountCompaniesPostJSONRequestBody) (*CountCompaniesPostResponse, error) {
-	return c.ClientWithResponses.CountCompaniesPostWithResponse(ctx, body)
+func (c *CompaniesAPIClient) CountCompaniesPost(ctx context.Context, body CountCompaniesPostJSONRequestBody, reqEditors ...RequestEditorFn) (*CountCompaniesPostResponse, error) {
+	return c.ClientWithResponses.CountCompaniesPostWithResponse(c.callContext(ctx), body, reqEditors...)
 }
 
 // === Companies Analytics ===
 
-func (c *CompaniesAPIClient) FetchCompaniesAnalytics(ctx context.Context, params *FetchCompaniesAnalyticsParams) (*FetchCompaniesAnalyticsResponse, error) {
-	return c.ClientWithResponses.FetchCompaniesAnalyticsWithResponse(ctx, params)
+func (c *CompaniesAPIClient) FetchCompaniesAnalytics(ctx context.Context, params *FetchCompaniesAnalyticsParams, reqEditors ...RequestEditorFn) (*FetchCompaniesAnalyticsResponse, error) {
+	return c.ClientWithResponses.FetchCompaniesAnalyticsWithResponse(c.callContext(ctx), params, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) ExportCompaniesAnalytics(ctx context.Context, body ExportCompaniesAnalyticsJSONRequestBody) (*ExportCompaniesAnalyticsResponse, error) {
-	return c.ClientWithResponses.ExportCompaniesAnalyticsWithResponse(ctx, body)
+func (c *CompaniesAPIClient) ExportCompaniesAnalytics(ctx context.Context, body ExportCompaniesAnalyticsJSONRequestBody, reqEditors ...RequestEditorFn) (*ExportCompaniesAnalyticsResponse, error) {
+	return c.ClientWithResponses.ExportCompaniesAnalyticsWithResponse(c.callContext(ctx), body, reqEditors...)
 }
 
 // === Company Operations ===
 
-func (c *CompaniesAPIClient) FetchCompany(ctx context.Context, domain string, params *FetchCompanyParams) (*FetchCompanyResponse, error) {
-	return c.ClientWithResponses.FetchCompanyWithResponse(ctx, domain, params)
+func (c *CompaniesAPIClient) FetchCompany(ctx context.Context, domain string, params *FetchCompanyParams, reqEditors ...RequestEditorFn) (*FetchCompanyResponse, error) {
+	return c.ClientWithResponses.FetchCompanyWithResponse(c.callContext(ctx), domain, params, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) FetchCompanyByEmail(ctx context.Context, params *FetchCompanyByEmailParams) (*FetchCompanyByEmailResponse, error) {
-	return c.ClientWithResponses.FetchCompanyByEmailWithResponse(ctx, params)
+// FetchCompanyByEmail returns ErrInvalidEmail without calling the API when the email is malformed
+func (c *CompaniesAPIClient) FetchCompanyByEmail(ctx context.Context, params *FetchCompanyByEmailParams, reqEditors ...RequestEditorFn) (*FetchCompanyByEmailResponse, error) {
+	if params != nil {
+		if err := ValidateEmail(params.Email); err != nil {
+			return nil, err
+		}
+	}
+	return c.ClientWithResponses.FetchCompanyByEmailWithResponse(c.callContext(ctx), params, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) FetchCompanyBySocial(ctx context.Context, params *FetchCompanyBySocialParams) (*FetchCompanyBySocialResponse, error) {
-	return c.ClientWithResponses.FetchCompanyBySocialWithResponse(ctx, params)
+func (c *CompaniesAPIClient) FetchCompanyBySocial(ctx context.Context, params *FetchCompanyBySocialParams, reqEditors ...RequestEditorFn) (*FetchCompanyBySocialResponse, error) {
+	return c.ClientWithResponses.FetchCompanyBySocialWithResponse(c.callContext(ctx), params, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) FetchCompanyContext(ctx context.Context, domain string) (*FetchCompanyContextResponse, error) {
-	return c.ClientWithResponses.FetchCompanyContextWithResponse(ctx, domain)
+func (c *CompaniesAPIClient) FetchCompanyContext(ctx context.Context, domain string, reqEditors ...RequestEditorFn) (*FetchCompanyContextResponse, error) {
+	return c.ClientWithResponses.FetchCompanyContextWithResponse(c.callContext(ctx), domain, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) FetchCompanyEmailPatterns(ctx context.Context, domain string, params *FetchCompanyEmailPatternsParams) (*FetchCompanyEmailPatternsResponse, error) {
-	return c.ClientWithResponses.FetchCompanyEmailPatternsWithResponse(ctx, domain, params)
+func (c *CompaniesAPIClient) FetchCompanyEmailPatterns(ctx context.Context, domain string, params *FetchCompanyEmailPatternsParams, reqEditors ...RequestEditorFn) (*FetchCompanyEmailPatternsResponse, error) {
+	return c.ClientWithResponses.FetchCompanyEmailPatternsWithResponse(c.callContext(ctx), domain, params, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) AskCompany(ctx context.Context, domain string, body AskCompanyJSONRequestBody) (*AskCompanyResponse, error) {
-	return c.ClientWithResponses.AskCompanyWithResponse(ctx, domain, body)
+func (c *CompaniesAPIClient) AskCompany(ctx context.Context, domain string, body AskCompanyJSONRequestBody, reqEditors ...RequestEditorFn) (*AskCompanyResponse, error) {
+	return c.ClientWithResponses.AskCompanyWithResponse(c.callContext(ctx), domain, body, reqEditors...)
 }
 
 // === Industries ===
 
-func (c *CompaniesAPIClient) SearchIndustries(ctx context.Context, params *SearchIndustriesParams) (*SearchIndustriesResponse, error) {
-	return c.ClientWithResponses.SearchIndustriesWithResponse(ctx, params)
+func (c *CompaniesAPIClient) SearchIndustries(ctx context.Context, params *SearchIndustriesParams, reqEditors ...RequestEditorFn) (*SearchIndustriesResponse, error) {
+	return c.ClientWithResponses.SearchIndustriesWithResponse(c.callContext(ctx), params, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) SearchIndustriesSimilar(ctx context.Context, params *SearchIndustriesSimilarParams) (*SearchIndustriesSimilarResponse, error) {
-	return c.ClientWithResponses.SearchIndustriesSimilarWithResponse(ctx, params)
+func (c *CompaniesAPIClient) SearchIndustriesSimilar(ctx context.Context, params *SearchIndustriesSimilarParams, reqEditors ...RequestEditorFn) (*SearchIndustriesSimilarResponse, error) {
+	return c.ClientWithResponses.SearchIndustriesSimilarWithResponse(c.callContext(ctx), params, reqEditors...)
 }
 
 // === Job Titles ===
 
-func (c *CompaniesAPIClient) EnrichJobTitles(ctx context.Context, params *EnrichJobTitlesParams) (*EnrichJobTitlesResponse, error) {
-	return c.ClientWithResponses.EnrichJobTitlesWithResponse(ctx, params)
+func (c *CompaniesAPIClient) EnrichJobTitles(ctx context.Context, params *EnrichJobTitlesParams, reqEditors ...RequestEditorFn) (*EnrichJobTitlesResponse, error) {
+	return c.ClientWithResponses.EnrichJobTitlesWithResponse(c.callContext(ctx), params, reqEditors...)
 }
 
 // === Lists ===
 
-func (c *CompaniesAPIClient) FetchLists(ctx context.Context, params *FetchListsParams) (*FetchListsResponse, error) {
-	return c.ClientWithResponses.FetchListsWithResponse(ctx, params)
+func (c *CompaniesAPIClient) FetchLists(ctx context.Context, params *FetchListsParams, reqEditors ...RequestEditorFn) (*FetchListsResponse, error) {
+	return c.ClientWithResponses.FetchListsWithResponse(c.callContext(ctx), params, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) CreateList(ctx context.Context, body CreateListJSONRequestBody) (*CreateListResponse, error) {
-	return c.ClientWithResponses.CreateListWithResponse(ctx, body)
+func (c *CompaniesAPIClient) CreateList(ctx context.Context, body CreateListJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateListResponse, error) {
+	return c.ClientWithResponses.CreateListWithResponse(c.callContext(ctx), body, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) DeleteList(ctx context.Context, listId float32) (*DeleteListResponse, error) {
-	return c.ClientWithResponses.DeleteListWithResponse(ctx, listId)
+func (c *CompaniesAPIClient) DeleteList(ctx context.Context, listId float32, reqEditors ...RequestEditorFn) (*DeleteListResponse, error) {
+	return c.ClientWithResponses.DeleteListWithResponse(c.callContext(ctx), listId, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) UpdateList(ctx context.Context, listId float32, body UpdateListJSONRequestBody) (*UpdateListResponse, error) {
-	return c.ClientWithResponses.UpdateListWithResponse(ctx, listId, body)
+func (c *CompaniesAPIClient) UpdateList(ctx context.Context, listId float32, body UpdateListJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateListResponse, error) {
+	return c.ClientWithResponses.UpdateListWithResponse(c.callContext(ctx), listId, body, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) FetchCompaniesInList(ctx context.Context, listId float32, params *FetchCompaniesInListParams) (*FetchCompaniesInListResponse, error) {
-	return c.ClientWithResponses.FetchCompaniesInListWithResponse(ctx, listId, params)
+func (c *CompaniesAPIClient) FetchCompaniesInList(ctx context.Context, listId float32, params *FetchCompaniesInListParams, reqEditors ...RequestEditorFn) (*FetchCompaniesInListResponse, error) {
+	return c.ClientWithResponses.FetchCompaniesInListWithResponse(c.callContext(ctx), listId, params, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) FetchCompaniesInListPost(ctx context.Context, listId float32, body FetchCompaniesInListPostJSONRequestBody) (*FetchCompaniesInListPostResponse, error) {
-	return c.ClientWithResponses.FetchCompaniesInListPostWithResponse(ctx, listId, body)
+func (c *CompaniesAPIClient) FetchCompaniesInListPost(ctx context.Context, listId float32, body FetchCompaniesInListPostJSONRequestBody, reqEditors ...RequestEditorFn) (*FetchCompaniesInListPostResponse, error) {
+	return c.ClientWithResponses.FetchCompaniesInListPostWithResponse(c.callContext(ctx), listId, body, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) ToggleCompaniesInList(ctx context.Context, listId float32, body ToggleCompaniesInListJSONRequestBody) (*ToggleCompaniesInListResponse, error) {
-	return c.ClientWithResponses.ToggleCompaniesInListWithResponse(ctx, listId, body)
+func (c *CompaniesAPIClient) ToggleCompaniesInList(ctx context.Context, listId float32, body ToggleCompaniesInListJSONRequestBody, reqEditors ...RequestEditorFn) (*ToggleCompaniesInListResponse, error) {
+	return c.ClientWithResponses.ToggleCompaniesInListWithResponse(c.callContext(ctx), listId, body, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) FetchCompanyInList(ctx context.Context, listId float32, domain string) (*FetchCompanyInListResponse, error) {
-	return c.ClientWithResponses.FetchCompanyInListWithResponse(ctx, listId, domain)
+func (c *CompaniesAPIClient) FetchCompanyInList(ctx context.Context, listId float32, domain string, reqEditors ...RequestEditorFn) (*FetchCompanyInListResponse, error) {
+	return c.ClientWithResponses.FetchCompanyInListWithResponse(c.callContext(ctx), listId, domain, reqEditors...)
 }
 
 // === Locations ===
 
-func (c *CompaniesAPIClient) SearchCities(ctx context.Context, params *SearchCitiesParams) (*SearchCitiesResponse, error) {
-	return c.ClientWithResponses.SearchCitiesWithResponse(ctx, params)
+func (c *CompaniesAPIClient) SearchCities(ctx context.Context, params *SearchCitiesParams, reqEditors ...RequestEditorFn) (*SearchCitiesResponse, error) {
+	return c.ClientWithResponses.SearchCitiesWithResponse(c.callContext(ctx), params, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) SearchContinents(ctx context.Context, params *SearchContinentsParams) (*SearchContinentsResponse, error) {
-	return c.ClientWithResponses.SearchContinentsWithResponse(ctx, params)
+func (c *CompaniesAPIClient) SearchContinents(ctx context.Context, params *SearchContinentsParams, reqEditors ...RequestEditorFn) (*SearchContinentsResponse, error) {
+	return c.ClientWithResponses.SearchContinentsWithResponse(c.callContext(ctx), params, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) SearchCounties(ctx context.Context, params *SearchCountiesParams) (*SearchCountiesResponse, error) {
-	return c.ClientWithResponses.SearchCountiesWithResponse(ctx, params)
+func (c *CompaniesAPIClient) SearchCounties(ctx context.Context, params *SearchCountiesParams, reqEditors ...RequestEditorFn) (*SearchCountiesResponse, error) {
+	return c.ClientWithResponses.SearchCountiesWithResponse(c.callContext(ctx), params, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) SearchCountries(ctx context.Context, params *SearchCountriesParams) (*SearchCountriesResponse, error) {
-	return c.ClientWithResponses.SearchCountriesWithResponse(ctx, params)
+func (c *CompaniesAPIClient) SearchCountries(ctx context.Context, params *SearchCountriesParams, reqEditors ...RequestEditorFn) (*SearchCountriesResponse, error) {
+	return c.ClientWithResponses.SearchCountriesWithResponse(c.callContext(ctx), params, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) SearchStates(ctx context.Context, params *SearchStatesParams) (*SearchStatesResponse, error) {
-	return c.ClientWithResponses.SearchStatesWithResponse(ctx, params)
+func (c *CompaniesAPIClient) SearchStates(ctx context.Context, params *SearchStatesParams, reqEditors ...RequestEditorFn) (*SearchStatesResponse, error) {
+	return c.ClientWithResponses.SearchStatesWithResponse(c.callContext(ctx), params, reqEditors...)
 }
 
 // === OpenAPI ===
 
-func (c *CompaniesAPIClient) FetchOpenApi(ctx context.Context) (*FetchOpenApiResponse, error) {
-	return c.ClientWithResponses.FetchOpenApiWithResponse(ctx)
+func (c *CompaniesAPIClient) FetchOpenApi(ctx context.Context, reqEditors ...RequestEditorFn) (*FetchOpenApiResponse, error) {
+	return c.ClientWithResponses.FetchOpenApiWithResponse(c.callContext(ctx), reqEditors...)
 }
 
 // === Prompts ===
 
-func (c *CompaniesAPIClient) FetchPrompts(ctx context.Context, params *FetchPromptsParams) (*FetchPromptsResponse, error) {
-	return c.ClientWithResponses.FetchPromptsWithResponse(ctx, params)
+func (c *CompaniesAPIClient) FetchPrompts(ctx context.Context, params *FetchPromptsParams, reqEditors ...RequestEditorFn) (*FetchPromptsResponse, error) {
+	return c.ClientWithResponses.FetchPromptsWithResponse(c.callContext(ctx), params, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) ProductPrompt(ctx context.Context, body ProductPromptJSONRequestBody) (*ProductPromptResponse, error) {
-	return c.ClientWithResponses.ProductPromptWithResponse(ctx, body)
+func (c *CompaniesAPIClient) ProductPrompt(ctx context.Context, body ProductPromptJSONRequestBody, reqEditors ...RequestEditorFn) (*ProductPromptResponse, error) {
+	return c.ClientWithResponses.ProductPromptWithResponse(c.callContext(ctx), body, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) PromptToSegmentation(ctx context.Context, body PromptToSegmentationJSONRequestBody) (*PromptToSegmentationResponse, error) {
-	return c.ClientWithResponses.PromptToSegmentationWithResponse(ctx, body)
+func (c *CompaniesAPIClient) PromptToSegmentation(ctx context.Context, body PromptToSegmentationJSONRequestBody, reqEditors ...RequestEditorFn) (*PromptToSegmentationResponse, error) {
+	return c.ClientWithResponses.PromptToSegmentationWithResponse(c.callContext(ctx), body, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) DeletePrompt(ctx context.Context, promptId float32) (*DeletePromptResponse, error) {
-	return c.ClientWithResponses.DeletePromptWithResponse(ctx, promptId)
+func (c *CompaniesAPIClient) DeletePrompt(ctx context.Context, promptId float32, reqEditors ...RequestEditorFn) (*DeletePromptResponse, error) {
+	return c.ClientWithResponses.DeletePromptWithResponse(c.callContext(ctx), promptId, reqEditors...)
 }
 
 // === Teams ===
 
-func (c *CompaniesAPIClient) FetchTeam(ctx context.Context, teamId float32) (*FetchTeamResponse, error) {
-	return c.ClientWithResponses.FetchTeamWithResponse(ctx, teamId)
+func (c *CompaniesAPIClient) FetchTeam(ctx context.Context, teamId float32, reqEditors ...RequestEditorFn) (*FetchTeamResponse, error) {
+	return c.ClientWithResponses.FetchTeamWithResponse(c.callContext(ctx), teamId, reqEditors...)
 }
 
-func (c *CompaniesAPIClient) UpdateTeam(ctx context.Context, teamId float32, body UpdateTeamJSONRequestBody) (*UpdateTeamResponse, error) {
-	return c.ClientWithResponses.UpdateTeamWithResponse(ctx, teamId, body)
+func (c *CompaniesAPIClient) UpdateTeam(ctx context.Context, teamId float32, body UpdateTeamJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateTeamResponse, error) {
+	return c.ClientWithResponses.UpdateTeamWithResponse(c.callContext(ctx), teamId, body, reqEditors...)
 }
 
 // === Technologies ===
 
-func (c *CompaniesAPIClient) SearchTechnologies(ctx context.Context, params *SearchTechnologiesParams) (*SearchTechnologiesResponse, error) {
-	return c.ClientWithResponses.SearchTechnologiesWithResponse(ctx, params)
+func (c *CompaniesAPIClient) SearchTechnologies(ctx context.Context, params *SearchTechnologiesParams, reqEditors ...RequestEditorFn) (*SearchTechnologiesResponse, error) {
+	return c.ClientWithResponses.SearchTechnologiesWithResponse(c.callContext(ctx), params, reqEditors...)
 }
 
 // === Users ===
 
-func (c *CompaniesAPIClient) FetchUser(ctx context.Context) (*FetchUserResponse, error) {
-	return c.ClientWithResponses.FetchUserWithResponse(ctx)
+func (c *CompaniesAPIClient) FetchUser(ctx context.Context, reqEditors ...RequestEditorFn) (*FetchUserResponse, error) {
+	return c.ClientWithResponses.FetchUserWithResponse(c.callContext(ctx), reqEditors...)
 } 
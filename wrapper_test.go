@@ -2,8 +2,13 @@ package thecompaniesapi_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
-	
+	"time"
+
 	"github.com/thecompaniesapi/sdk-go"
 )
 
@@ -181,4 +186,50 @@ func TestAdditionalMethods(t *testing.T) {
 	if err != nil {
 		t.Logf("FetchUser failed as expected: %v", err)
 	}
-} 
+}
+
+func TestCompaniesAPIClientConcurrentUse(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("Authorization") != "Basic rotated-token" || r.Header.Get("Tca-Visitor-Id") != "visitor" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"count":1}`))
+	}))
+	defer server.Close()
+
+	client, err := thecompaniesapi.ApiClient("test-api-key",
+		thecompaniesapi.WithCustomBaseURL(server.URL),
+		thecompaniesapi.WithVisitorID("visitor"),
+		thecompaniesapi.WithTokenProvider(func(ctx context.Context) (string, error) {
+			return "rotated-token", nil
+		}, time.Minute),
+	)
+	if err != nil {
+		t.Fatalf("ApiClient returned error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	var failures atomic.Int32
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response, err := client.CountCompanies(context.Background(), &thecompaniesapi.CountCompaniesParams{})
+			if err != nil || response.JSON200 == nil {
+				failures.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if failures.Load() != 0 {
+		t.Errorf("Expected all concurrent requests to succeed, %d failed", failures.Load())
+	}
+	if requests.Load() != 100 {
+		t.Errorf("Expected 100 requests, got %d", requests.Load())
+	}
+}